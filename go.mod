@@ -5,6 +5,7 @@ go 1.24.2
 require (
 	github.com/chromedp/cdproto v0.0.0-20250724212937-08a3db8b4327
 	github.com/chromedp/chromedp v0.14.2
+	golang.org/x/crypto v0.40.0
 )
 
 require (
@@ -0,0 +1,9 @@
+// Command skool-downloader downloads the videos of a skool.com classroom.
+// The scraping and downloading live in the skool package.
+package main
+
+import "skool-downloader/skool"
+
+func main() {
+	skool.Main()
+}
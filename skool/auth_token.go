@@ -0,0 +1,25 @@
+package skool
+
+import (
+	"strings"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+// authTokenEnv holds the -auth-token value, so the session stays out of shell history
+const authTokenEnv = "SKOOL_AUTH_TOKEN"
+
+// cookieFromAuthToken builds the skool.com session cookie from a bare
+// auth_token value. A pasted "auth_token=..." pair is accepted too.
+func cookieFromAuthToken(token string) *network.CookieParam {
+	token = strings.TrimSpace(token)
+	token = strings.TrimPrefix(token, skoolAuthCookie+"=")
+	return &network.CookieParam{
+		Name:     skoolAuthCookie,
+		Value:    token,
+		Domain:   ".skool.com",
+		Path:     "/",
+		Secure:   true,
+		HTTPOnly: true,
+	}
+}
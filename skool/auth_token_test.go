@@ -0,0 +1,25 @@
+package skool
+
+import "testing"
+
+func TestCookieFromAuthToken(t *testing.T) {
+	for _, token := range []string{"abc.def.ghi", "  abc.def.ghi\n", "auth_token=abc.def.ghi"} {
+		c := cookieFromAuthToken(token)
+		if c.Name != skoolAuthCookie || c.Value != "abc.def.ghi" {
+			t.Errorf("cookieFromAuthToken(%q) = %s=%s", token, c.Name, c.Value)
+		}
+		if c.Domain != ".skool.com" || c.Path != "/" || !c.Secure || !c.HTTPOnly {
+			t.Errorf("cookieFromAuthToken(%q) has wrong attributes: %+v", token, c)
+		}
+	}
+}
+
+func TestLoadCookies_AuthToken(t *testing.T) {
+	cookies, err := loadCookies(Config{AuthToken: "abc.def.ghi"})
+	if err != nil {
+		t.Fatalf("loadCookies() error = %v", err)
+	}
+	if err := validateCookies(cookies); err != nil {
+		t.Errorf("Expected the auth token cookie to pass validation, got %v", err)
+	}
+}
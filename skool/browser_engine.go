@@ -0,0 +1,52 @@
+package skool
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Browser engines: -engine only accepts Chromium, Firefox is only recognized
+// so a Firefox -browser gets a clear error
+const (
+	engineChromium = "chromium"
+	engineFirefox  = "firefox"
+)
+
+// errFirefoxUnsupported is returned for a Firefox browser, which can't be driven
+var errFirefoxUnsupported = errors.New("Firefox is not supported. Please use a Chromium-based browser (Chrome, Chromium, Edge, Brave)")
+
+// validateEngine checks the -engine value, where empty means auto-detect
+func validateEngine(engine string) error {
+	switch engine {
+	case "", engineChromium:
+		return nil
+	case engineFirefox:
+		return errFirefoxUnsupported
+	default:
+		return fmt.Errorf("unknown -engine %q (use %s)", engine, engineChromium)
+	}
+}
+
+// browserEngine returns the engine to launch browserPath with. An explicit
+// -engine wins; otherwise it's guessed from the executable name.
+func browserEngine(engine, browserPath string) string {
+	if engine != "" {
+		return engine
+	}
+	if strings.Contains(strings.ToLower(filepath.Base(browserPath)), "firefox") {
+		return engineFirefox
+	}
+	return engineChromium
+}
+
+// launchEngine returns the engine setupBrowser launches browserPath with, or
+// an error if that engine can't be driven
+func launchEngine(engine, browserPath string) (string, error) {
+	resolved := browserEngine(engine, browserPath)
+	if resolved == engineFirefox {
+		return "", errFirefoxUnsupported
+	}
+	return resolved, nil
+}
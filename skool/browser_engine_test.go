@@ -0,0 +1,69 @@
+package skool
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBrowserEngine(t *testing.T) {
+	tests := []struct {
+		name     string
+		engine   string
+		path     string
+		expected string
+	}{
+		{"Detected Chromium", "", "/usr/bin/google-chrome", engineChromium},
+		{"Detected Firefox", "", "/usr/bin/firefox", engineFirefox},
+		{"Detected Firefox ignores case", "", `C:\Program Files\Mozilla Firefox\Firefox.exe`, engineFirefox},
+		{"Renamed Chromium wrapper", engineChromium, "/opt/wrappers/firefox-lookalike", engineChromium},
+		{"Forced Firefox", engineFirefox, "/usr/local/bin/browser", engineFirefox},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := browserEngine(tt.engine, tt.path); got != tt.expected {
+				t.Errorf("browserEngine(%q, %q) = %q, want %q", tt.engine, tt.path, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateEngine(t *testing.T) {
+	for _, engine := range []string{"", engineChromium} {
+		if err := validateEngine(engine); err != nil {
+			t.Errorf("validateEngine(%q) returned %v", engine, err)
+		}
+	}
+	if err := validateEngine(engineFirefox); !errors.Is(err, errFirefoxUnsupported) {
+		t.Errorf("validateEngine(%q) = %v, want errFirefoxUnsupported", engineFirefox, err)
+	}
+	if err := validateEngine("webkit"); err == nil {
+		t.Error("Expected an error for an unknown engine")
+	}
+}
+
+func TestLaunchEngine(t *testing.T) {
+	tests := []struct {
+		name     string
+		engine   string
+		path     string
+		expected string
+		wantErr  bool
+	}{
+		{"Detected Chromium", "", "/usr/bin/chromium", engineChromium, false},
+		{"Detected Firefox", "", "/usr/bin/firefox", "", true},
+		{"Chromium wrapper named like Firefox", engineChromium, "/opt/wrappers/firefox-lookalike", engineChromium, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := launchEngine(tt.engine, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("launchEngine(%q, %q) error = %v, wantErr %v", tt.engine, tt.path, err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("launchEngine(%q, %q) = %q, want %q", tt.engine, tt.path, got, tt.expected)
+			}
+		})
+	}
+}
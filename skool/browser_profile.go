@@ -0,0 +1,40 @@
+package skool
+
+import (
+	"context"
+	"fmt"
+)
+
+// profileHasSession reports whether the persistent browser profile is already
+// logged in to Skool
+func profileHasSession(ctx context.Context, config Config) bool {
+	if config.ProfileDir == "" {
+		return false
+	}
+
+	cookies, err := browserCookies(ctx)
+	if err != nil {
+		console.Debugf("Couldn't read cookies from profile: %v", err)
+		return false
+	}
+	if !hasAuthCookie(cookies) {
+		return false
+	}
+
+	console.Auth("Reusing the logged-in session from profile:", config.ProfileDir)
+	return true
+}
+
+// scrapeWithProfile scrapes using only the session stored in -profile-dir
+func scrapeWithProfile(parent context.Context, config Config) (*scrapeResult, error) {
+	ctx, cancel, err := setupBrowser(parent, config, config.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	if !profileHasSession(ctx, config) {
+		return nil, fmt.Errorf("no Skool session found in profile %s, log in once with -profile-dir=%s plus -manual-login, -email/-password or -cookies", config.ProfileDir, config.ProfileDir)
+	}
+	return navigateAndScrape(ctx, config)
+}
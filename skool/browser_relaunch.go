@@ -0,0 +1,55 @@
+package skool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// browserGoneMessages are parts of the errors chromedp returns once the
+// browser process is gone. Most errors are wrapped with %v on the way up, so
+// they are matched by text.
+var browserGoneMessages = []string{
+	"context canceled",
+	"operation was canceled",
+	"websocket: close",
+	"use of closed network connection",
+	"connection reset by peer",
+	"broken pipe",
+	"target closed",
+}
+
+// isBrowserGone reports whether err means the browser crashed or was killed,
+// as opposed to the run being cancelled (parent done) or the scrape failing
+func isBrowserGone(parent context.Context, err error) bool {
+	if err == nil || parent.Err() != nil {
+		return false
+	}
+	if errors.Is(err, context.Canceled) {
+		return true
+	}
+	msg := err.Error()
+	for _, gone := range browserGoneMessages {
+		if strings.Contains(msg, gone) {
+			return true
+		}
+	}
+	return false
+}
+
+// withBrowserRelaunch runs scrape, which launches its own browser, and runs it
+// once more in a new browser if the first one died along the way
+func withBrowserRelaunch(parent context.Context, scrape func(context.Context) (*scrapeResult, error)) (*scrapeResult, error) {
+	result, err := scrape(parent)
+	if !isBrowserGone(parent, err) {
+		return result, err
+	}
+
+	console.Warningf("The browser closed unexpectedly (%v), relaunching it and retrying once...", err)
+	result, err = scrape(parent)
+	if isBrowserGone(parent, err) {
+		return nil, fmt.Errorf("the browser closed unexpectedly twice, it may be crashing or getting killed (check memory limits, or try -chrome-flag=disable-dev-shm-usage): %w", err)
+	}
+	return result, err
+}
@@ -0,0 +1,101 @@
+package skool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/chromedp/chromedp"
+)
+
+func TestIsBrowserGone(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		parent context.Context
+		err    error
+		want   bool
+	}{
+		{"No error", context.Background(), nil, false},
+		{"Browser context cancelled", context.Background(), fmt.Errorf("failed to navigate to classroom: %v", context.Canceled), true},
+		{"Websocket closed", context.Background(), errors.New("websocket: close 1006 (abnormal closure)"), true},
+		{"Run cancelled", cancelled, context.Canceled, false},
+		{"Browser timeout", context.Background(), context.DeadlineExceeded, false},
+		{"Login failed", context.Background(), errInvalidCredentials, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isBrowserGone(tt.parent, tt.err); got != tt.want {
+				t.Errorf("isBrowserGone(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// dyingAllocator simulates the allocator going away under chromedp, which is
+// what happens when the browser process is killed
+func dyingAllocator(parent context.Context) error {
+	allocCtx, kill := chromedp.NewRemoteAllocator(parent, "ws://127.0.0.1:1/devtools/browser/gone")
+	ctx, cancel := chromedp.NewContext(allocCtx)
+	defer cancel()
+	kill()
+	return chromedp.Run(ctx)
+}
+
+func TestWithBrowserRelaunch(t *testing.T) {
+	previous := console
+	console = newLogger(levelQuiet, io.Discard)
+	defer func() {
+		console = previous
+	}()
+
+	t.Run("Retries once after the browser dies", func(t *testing.T) {
+		attempts := 0
+		result, err := withBrowserRelaunch(context.Background(), func(ctx context.Context) (*scrapeResult, error) {
+			attempts++
+			if attempts == 1 {
+				if err := dyingAllocator(ctx); err != nil {
+					return nil, fmt.Errorf("failed to navigate to classroom: %v", err)
+				}
+				t.Fatal("Expected chromedp to fail without an allocator")
+			}
+			return &scrapeResult{Videos: []VideoEntry{{URL: "https://www.loom.com/share/abc"}}}, nil
+		})
+		if err != nil || attempts != 2 || len(result.Videos) != 1 {
+			t.Errorf("Expected a successful retry, got %d attempt(s), %v", attempts, err)
+		}
+	})
+
+	t.Run("Gives up after the second death", func(t *testing.T) {
+		attempts := 0
+		var last error
+		_, err := withBrowserRelaunch(context.Background(), func(ctx context.Context) (*scrapeResult, error) {
+			attempts++
+			last = fmt.Errorf("failed to navigate to classroom: %w", dyingAllocator(ctx))
+			return nil, last
+		})
+		if err == nil || attempts != 2 {
+			t.Fatalf("Expected an error after 2 attempts, got %d attempt(s), %v", attempts, err)
+		}
+		// The browser's own error is kept for diagnosis
+		if !errors.Is(err, last) {
+			t.Errorf("Expected the second attempt's error to be wrapped, got %v", err)
+		}
+	})
+
+	t.Run("Other errors aren't retried", func(t *testing.T) {
+		attempts := 0
+		_, err := withBrowserRelaunch(context.Background(), func(ctx context.Context) (*scrapeResult, error) {
+			attempts++
+			return nil, errInvalidCredentials
+		})
+		if !errors.Is(err, errInvalidCredentials) || attempts != 1 {
+			t.Errorf("Expected the login error after 1 attempt, got %d attempt(s), %v", attempts, err)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package skool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// ErrCaptchaRequired is returned when Skool or Cloudflare shows a challenge
+// that can't be solved in headless mode
+var ErrCaptchaRequired = errors.New("captcha or Cloudflare challenge required, rerun with -headless=false and solve it in the browser window")
+
+// challengePollInterval is how often the page is re-checked while waiting for
+// the user to solve a challenge
+const challengePollInterval = 2 * time.Second
+
+// challengeTitleMarkers are page titles used by Cloudflare interstitials
+var challengeTitleMarkers = []string{
+	"just a moment",
+	"attention required",
+	"verify you are human",
+}
+
+// challengeContentMarkers identify visible captcha and challenge widgets
+var challengeContentMarkers = []string{
+	"cf-turnstile",
+	"cf-challenge",
+	"challenge-form",
+	"checking your browser",
+	"verify you are human",
+	"g-recaptcha",
+	"h-captcha",
+}
+
+// detectChallenge reports whether the page title or HTML contains a known
+// captcha or Cloudflare challenge marker
+func detectChallenge(title, html string) bool {
+	title = strings.ToLower(title)
+	for _, marker := range challengeTitleMarkers {
+		if strings.Contains(title, marker) {
+			return true
+		}
+	}
+
+	html = strings.ToLower(html)
+	for _, marker := range challengeContentMarkers {
+		if strings.Contains(html, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// pageHasChallenge checks the current page for a captcha or challenge
+func pageHasChallenge(ctx context.Context) (bool, error) {
+	var title, html string
+	if err := chromedp.Run(ctx, chromedp.Tasks{
+		chromedp.Title(&title),
+		chromedp.OuterHTML("html", &html),
+	}); err != nil {
+		return false, fmt.Errorf("failed to check for captcha: %v", err)
+	}
+	return detectChallenge(title, html), nil
+}
+
+// handleChallenge returns ErrCaptchaRequired if the current page shows a
+// challenge in headless mode. With a visible browser it waits for the user to
+// solve it, reporting whether a challenge was shown.
+func handleChallenge(ctx context.Context, headless bool) (bool, error) {
+	found, err := pageHasChallenge(ctx)
+	if err != nil || !found {
+		return false, err
+	}
+	if headless {
+		return true, ErrCaptchaRequired
+	}
+
+	console.Warning("Captcha or Cloudflare challenge detected, please solve it in the browser window...")
+	for found {
+		select {
+		case <-ctx.Done():
+			return true, fmt.Errorf("timed out waiting for the challenge to be solved: %v", ctx.Err())
+		case <-time.After(challengePollInterval):
+		}
+
+		if found, err = pageHasChallenge(ctx); err != nil {
+			return true, err
+		}
+	}
+
+	console.Success("Challenge solved, continuing...")
+	return true, nil
+}
@@ -0,0 +1,63 @@
+package skool
+
+import "testing"
+
+func TestDetectChallenge(t *testing.T) {
+	tests := []struct {
+		name     string
+		title    string
+		html     string
+		expected bool
+	}{
+		{
+			name:     "Cloudflare interstitial title",
+			title:    "Just a moment...",
+			html:     "<html><body></body></html>",
+			expected: true,
+		},
+		{
+			name:     "Turnstile widget",
+			title:    "Skool",
+			html:     `<div class="cf-turnstile" data-sitekey="x"></div>`,
+			expected: true,
+		},
+		{
+			name:     "reCAPTCHA widget",
+			title:    "Log in | Skool",
+			html:     `<div class="g-recaptcha" data-sitekey="x"></div>`,
+			expected: true,
+		},
+		{
+			name:     "hCaptcha widget",
+			title:    "Log in | Skool",
+			html:     `<div class="h-captcha"></div>`,
+			expected: true,
+		},
+		{
+			name:     "Verification text",
+			title:    "Skool",
+			html:     "<p>Checking your browser before accessing skool.com</p>",
+			expected: true,
+		},
+		{
+			name:     "Regular login page",
+			title:    "Log in | Skool",
+			html:     `<form><input type="email"><input type="password"><button type="submit">Log In</button></form>`,
+			expected: false,
+		},
+		{
+			name:     "Incorrect password",
+			title:    "Log in | Skool",
+			html:     "<p>Incorrect password</p>",
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectChallenge(tt.title, tt.html); got != tt.expected {
+				t.Errorf("detectChallenge() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package skool
+
+import (
+	"context"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// manifestExtensions are streaming manifests, preferred over single media
+// requests because they describe the whole video
+var manifestExtensions = []string{".m3u8", ".mpd"}
+
+// playVideosJS starts every <video> on the page muted, so the player requests
+// its stream even when autoplay is blocked
+const playVideosJS = `document.querySelectorAll('video').forEach(v => { v.muted = true; v.play().catch(() => {}); })`
+
+// isManifestURL reports whether link is an HLS or DASH manifest
+func isManifestURL(link string) bool {
+	u, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+	ext := strings.ToLower(path.Ext(u.Path))
+	for _, manifestExt := range manifestExtensions {
+		if ext == manifestExt {
+			return true
+		}
+	}
+	return false
+}
+
+// isMediaRequest reports whether a request seen during playback is worth
+// handing to yt-dlp: a streaming manifest, or a media request for a video file
+func isMediaRequest(link string, resourceType network.ResourceType) bool {
+	if isManifestURL(link) {
+		return true
+	}
+	return resourceType == network.ResourceTypeMedia && isDirectVideoURL(link)
+}
+
+// pickCapturedURL returns the first manifest among the captured requests,
+// falling back to the first media file, or "" if nothing was captured
+func pickCapturedURL(captured []string) string {
+	for _, link := range captured {
+		if isManifestURL(link) {
+			return link
+		}
+	}
+	if len(captured) > 0 {
+		return captured[0]
+	}
+	return ""
+}
+
+// needsCapture reports whether video is on a host yt-dlp can't resolve.
+// Loom, YouTube and Google Drive have yt-dlp extractors and direct links are
+// already files, so capturing them would only swap a working URL for a
+// short-lived stream.
+func needsCapture(video VideoEntry) bool {
+	switch video.Platform {
+	case platformLoom, platformYouTube, platformDrive, platformDirect:
+		return false
+	}
+	return true
+}
+
+// captureCandidates returns the videos to capture, once per video even when
+// several lessons link it
+func captureCandidates(videos []VideoEntry) []VideoEntry {
+	unique, _ := dedupeVideos(videos)
+	var candidates []VideoEntry
+	for _, video := range unique {
+		if needsCapture(video) {
+			candidates = append(candidates, video)
+		}
+	}
+	return candidates
+}
+
+// captureMediaURLs plays the page of each video yt-dlp can't resolve in the
+// browser and records the stream it requests as the MediaURL of every entry
+// for that video. Videos where nothing is captured keep downloading from
+// their page URL.
+func captureMediaURLs(ctx context.Context, config Config, videos []VideoEntry) {
+	candidates := captureCandidates(videos)
+	if len(candidates) == 0 {
+		console.Debug("No videos need network capture, yt-dlp resolves them all")
+		return
+	}
+	console.Infof("Capturing network requests for %d video(s), this is experimental", len(candidates))
+	defer timings.Start("network capture")()
+
+	wait := time.Duration(config.WaitTime) * time.Second
+	captured := make(map[string]string)
+	for _, video := range candidates {
+		mediaURL, err := captureMediaURL(ctx, video.URL, wait)
+		if err != nil {
+			console.Warningf("Failed to capture network requests for %s: %v", video.URL, err)
+			continue
+		}
+		if mediaURL == "" {
+			console.Warningf("No stream requested by %s, downloading the page URL instead", video.URL)
+			continue
+		}
+		console.Debugf("Captured %s for %s", mediaURL, video.URL)
+		captured[video.Key()] = mediaURL
+	}
+	for i := range videos {
+		if mediaURL, ok := captured[videos[i].Key()]; ok {
+			videos[i].MediaURL = mediaURL
+		}
+	}
+}
+
+// captureMediaURL opens pageURL in a new tab, starts playback and returns the
+// best media request seen within wait
+func captureMediaURL(ctx context.Context, pageURL string, wait time.Duration) (string, error) {
+	tabCtx, cancel := newBrowserTab(ctx)
+	defer cancel()
+
+	var mu sync.Mutex
+	var captured []string
+	chromedp.ListenTarget(tabCtx, func(ev interface{}) {
+		if e, ok := ev.(*network.EventRequestWillBeSent); ok && isMediaRequest(e.Request.URL, e.Type) {
+			mu.Lock()
+			captured = append(captured, e.Request.URL)
+			mu.Unlock()
+		}
+	})
+
+	if err := chromedp.Run(tabCtx,
+		network.Enable(),
+		chromedp.Navigate(pageURL),
+		chromedp.Evaluate(playVideosJS, nil),
+		chromedp.Sleep(wait),
+	); err != nil {
+		return "", err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	return pickCapturedURL(captured), nil
+}
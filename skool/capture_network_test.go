@@ -0,0 +1,69 @@
+package skool
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+func TestIsMediaRequest(t *testing.T) {
+	tests := []struct {
+		url          string
+		resourceType network.ResourceType
+		expected     bool
+	}{
+		{"https://cdn.example.com/videos/abc/master.m3u8?token=x", network.ResourceTypeXHR, true},
+		{"https://cdn.example.com/videos/abc/manifest.MPD", network.ResourceTypeFetch, true},
+		{"https://cdn.example.com/videos/abc.mp4", network.ResourceTypeMedia, true},
+		{"https://cdn.example.com/videos/abc.mp4", network.ResourceTypeImage, false},
+		{"https://cdn.example.com/videos/abc/segment-1.ts", network.ResourceTypeXHR, false},
+		{"https://cdn.example.com/player.js", network.ResourceTypeScript, false},
+	}
+	for _, tt := range tests {
+		if got := isMediaRequest(tt.url, tt.resourceType); got != tt.expected {
+			t.Errorf("isMediaRequest(%q, %s) = %v, want %v", tt.url, tt.resourceType, got, tt.expected)
+		}
+	}
+}
+
+func TestPickCapturedURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		captured []string
+		expected string
+	}{
+		{"Nothing", nil, ""},
+		{"Manifest wins over media", []string{"https://cdn.example.com/a.mp4", "https://cdn.example.com/a/master.m3u8"}, "https://cdn.example.com/a/master.m3u8"},
+		{"First manifest", []string{"https://cdn.example.com/a.mpd", "https://cdn.example.com/b.m3u8"}, "https://cdn.example.com/a.mpd"},
+		{"Media only", []string{"https://cdn.example.com/a.mp4"}, "https://cdn.example.com/a.mp4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pickCapturedURL(tt.captured); got != tt.expected {
+				t.Errorf("pickCapturedURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCaptureCandidates(t *testing.T) {
+	videos := []VideoEntry{
+		newVideoEntry("https://www.loom.com/share/abc123", platformLoom),
+		newVideoEntry("https://www.youtube.com/watch?v=dQw4w9WgXcQ", platformYouTube),
+		newVideoEntry("https://drive.google.com/file/d/abc/view", platformDrive),
+		newVideoEntry("https://cdn.example.com/lesson.mp4", platformDirect),
+		{URL: "https://player.example.com/embed/42"},
+		{URL: "https://player.example.com/embed/42"},
+		{URL: "https://player.example.com/embed/7"},
+	}
+
+	var got []string
+	for _, video := range captureCandidates(videos) {
+		got = append(got, video.URL)
+	}
+	expected := []string{"https://player.example.com/embed/42", "https://player.example.com/embed/7"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("captureCandidates() = %q, want %q", got, expected)
+	}
+}
@@ -0,0 +1,94 @@
+package skool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// errNotLoggedIn is returned by -check-auth when Skool doesn't see a session
+var errNotLoggedIn = errors.New("not logged in to Skool")
+
+// authState is what -check-auth found on skool.com
+type authState struct {
+	LoggedIn bool
+	Reason   string // why the session was judged logged out
+}
+
+// decideAuthState judges the session from where skool.com landed, whether
+// its login button is showing and whether the auth_token cookie survived
+func decideAuthState(landedURL string, loginButton, authCookie bool) authState {
+	switch {
+	case strings.Contains(landedURL, "/login"):
+		return authState{Reason: "redirected to the login page"}
+	case !authCookie:
+		return authState{Reason: "Skool dropped the auth_token cookie"}
+	case loginButton:
+		return authState{Reason: "the page shows the Log In button"}
+	}
+	return authState{LoggedIn: true}
+}
+
+// checkAuth opens skool.com with the configured cookies, auth token or
+// profile and reports whether the session is logged in, without visiting
+// the classroom
+func checkAuth(parent context.Context, config Config) error {
+	var cookies []*network.CookieParam
+	if config.CookiesFile != "" || config.AuthToken != "" {
+		var err error
+		if cookies, err = loadCookies(config); err != nil {
+			return fmt.Errorf("error parsing cookies: %v", err)
+		}
+		// An expired or missing token can be told without a browser
+		if err := validateCookies(cookies); err != nil {
+			return fmt.Errorf("%w: %v", errNotLoggedIn, err)
+		}
+	}
+
+	ctx, cancel, err := setupBrowser(parent, config, config.Timeout)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	if len(cookies) > 0 {
+		if err := chromedp.Run(ctx, network.Enable(), network.SetCookies(cookies)); err != nil {
+			return fmt.Errorf("error setting cookies: %v", err)
+		}
+	}
+
+	var landedURL string
+	if err := chromedp.Run(ctx,
+		chromedp.Navigate(skoolBaseURL),
+		waitReady(pageReadyJS, initialWaitTime),
+		chromedp.Location(&landedURL),
+	); err != nil {
+		return fmt.Errorf("failed to open %s: %v", skoolBaseURL, err)
+	}
+	console.Info("Landed on:", landedURL)
+
+	_, loginButton, err := firstVisibleStrategy(loginButtonElement, func(xpath string) (bool, error) {
+		var visible bool
+		err := chromedp.Run(ctx, chromedp.Evaluate(visibleXPathJS(xpath), &visible))
+		return visible, err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to inspect the page: %v", err)
+	}
+	browserJar, err := browserCookies(ctx)
+	if err != nil {
+		return err
+	}
+
+	state := decideAuthState(landedURL, loginButton, hasAuthCookie(browserJar))
+	if !state.LoggedIn {
+		console.Error("Account state: logged out,", state.Reason)
+		return fmt.Errorf("%w: %s", errNotLoggedIn, state.Reason)
+	}
+	console.Success("Account state: logged in")
+	return nil
+}
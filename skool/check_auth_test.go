@@ -0,0 +1,53 @@
+package skool
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDecideAuthState(t *testing.T) {
+	tests := []struct {
+		name        string
+		landedURL   string
+		loginButton bool
+		authCookie  bool
+		loggedIn    bool
+	}{
+		{"Logged in", "https://www.skool.com/", false, true, true},
+		{"Redirected to login", "https://www.skool.com/login?redirect=%2F", false, true, false},
+		{"Cookie dropped", "https://www.skool.com/", false, false, false},
+		{"Login button showing", "https://www.skool.com/", true, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := decideAuthState(tt.landedURL, tt.loginButton, tt.authCookie)
+			if state.LoggedIn != tt.loggedIn {
+				t.Errorf("decideAuthState() = %+v, want logged in %v", state, tt.loggedIn)
+			}
+			if !state.LoggedIn && state.Reason == "" {
+				t.Error("Expected a reason for a logged out session")
+			}
+		})
+	}
+}
+
+func TestCheckAuth_ExpiredTokenWithoutBrowser(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cookies.json")
+	content := `[{"host": ".skool.com", "name": "auth_token", "value": "abc", "path": "/", "expiry": 1000000000}]`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	config := Config{CookiesFile: path}
+
+	// The expired token is caught before a browser would be needed
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := checkAuth(ctx, config); !errors.Is(err, errNotLoggedIn) {
+		t.Errorf("checkAuth() = %v, want errNotLoggedIn", err)
+	}
+}
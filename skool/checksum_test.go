@@ -0,0 +1,29 @@
+package skool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHashFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	if err := os.WriteFile(path, []byte("hello world"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := hashFile(path)
+	if err != nil {
+		t.Fatalf("hashFile failed: %v", err)
+	}
+	want := "b94d27b9934d3e08a52e52d7da7dabfac484efe37a5380ee9088f7ace2efcde9"
+	if got != want {
+		t.Errorf("hashFile() = %s, want %s", got, want)
+	}
+}
+
+func TestHashFile_Missing(t *testing.T) {
+	if _, err := hashFile(filepath.Join(t.TempDir(), "missing.mp4")); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
@@ -0,0 +1,139 @@
+package skool
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chromedp/chromedp"
+)
+
+// stringListFlag is a flag that can be given several times, collecting each value
+type stringListFlag []string
+
+func (l *stringListFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringListFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// chromeFlag is a Chromium command-line switch, without its leading dashes.
+// Value is true for a plain switch, or a string for --name=value; false
+// leaves the switch out.
+type chromeFlag struct {
+	Name  string
+	Value interface{}
+}
+
+// defaultChromeFlags are the switches every browser is launched with, on top
+// of chromedp's own defaults
+func defaultChromeFlags(config Config) []chromeFlag {
+	return []chromeFlag{
+		{"headless", config.Headless},
+		{"disable-gpu", true},
+		{"no-sandbox", true},
+		{"window-size", "1920,1080"},
+	}
+}
+
+// parseChromeFlagName strips the dashes from a switch name and checks it isn't empty
+func parseChromeFlagName(raw string) (string, error) {
+	name := strings.TrimLeft(strings.TrimSpace(raw), "-")
+	if name == "" || strings.ContainsAny(name, " =") {
+		return "", fmt.Errorf("invalid Chromium flag %q", raw)
+	}
+	return name, nil
+}
+
+// parseChromeFlag parses a -chrome-flag value: "name" or "name=true" turns a
+// switch on, "name=false" turns it off and "name=value" passes a value
+func parseChromeFlag(raw string) (chromeFlag, error) {
+	rawName, value, hasValue := strings.Cut(raw, "=")
+	name, err := parseChromeFlagName(rawName)
+	if err != nil {
+		return chromeFlag{}, err
+	}
+
+	switch {
+	case !hasValue || value == "true":
+		return chromeFlag{name, true}, nil
+	case value == "false":
+		return chromeFlag{name, false}, nil
+	default:
+		return chromeFlag{name, value}, nil
+	}
+}
+
+// validateHostResolverRules loosely checks -host-resolver-rules: comma
+// separated "MAP <host pattern> <replacement>" or "EXCLUDE <host pattern>"
+// rules, as Chromium's --host-resolver-rules takes them
+func validateHostResolverRules(rules string) error {
+	for _, rule := range strings.Split(rules, ",") {
+		fields := strings.Fields(rule)
+		switch {
+		case len(fields) == 3 && strings.EqualFold(fields[0], "MAP"):
+		case len(fields) == 2 && strings.EqualFold(fields[0], "EXCLUDE"):
+		default:
+			return fmt.Errorf("invalid -host-resolver-rules rule %q, use \"MAP <host> <ip or host>\" or \"EXCLUDE <host>\", separated by commas", strings.TrimSpace(rule))
+		}
+	}
+	return nil
+}
+
+// chromeFlags returns the switches to launch the browser with: the defaults,
+// -host-resolver-rules, then -chrome-flag, then every -no-chrome-flag turned
+// off. Later entries win.
+func chromeFlags(config Config) ([]chromeFlag, error) {
+	flags := defaultChromeFlags(config)
+	if config.HostResolverRules != "" {
+		flags = append(flags, chromeFlag{"host-resolver-rules", strings.TrimSpace(config.HostResolverRules)})
+	}
+	for _, raw := range config.ChromeFlags {
+		flag, err := parseChromeFlag(raw)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, flag)
+	}
+	for _, raw := range config.NoChromeFlags {
+		name, err := parseChromeFlagName(raw)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, chromeFlag{name, false})
+	}
+	return flags, nil
+}
+
+// browserAllocatorOptions returns the options to launch the browser at
+// execPath with, turning each switch into an option with newFlag (chromedp.Flag
+// outside of tests)
+func browserAllocatorOptions(config Config, execPath string, newFlag func(string, interface{}) chromedp.ExecAllocatorOption) ([]chromedp.ExecAllocatorOption, error) {
+	flags, err := chromeFlags(config)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := append([]chromedp.ExecAllocatorOption{}, chromedp.DefaultExecAllocatorOptions[:]...)
+	for _, flag := range flags {
+		opts = append(opts, newFlag(flag.Name, flag.Value))
+	}
+	opts = append(opts,
+		chromedp.UserAgent(config.UserAgent),
+		chromedp.ExecPath(execPath),
+	)
+
+	// A persistent profile keeps the session between runs instead of a fresh temp profile
+	if config.ProfileDir != "" {
+		opts = append(opts, chromedp.UserDataDir(config.ProfileDir))
+	}
+
+	// Scrape through the same proxy yt-dlp downloads through, or the first
+	// of several
+	if proxy := firstProxy(config.Proxy); proxy != "" {
+		opts = append(opts, chromedp.ProxyServer(proxy))
+	}
+	return opts, nil
+}
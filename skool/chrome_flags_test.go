@@ -0,0 +1,117 @@
+package skool
+
+import (
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/chromedp/chromedp"
+)
+
+func TestParseChromeFlag(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    chromeFlag
+		wantErr bool
+	}{
+		{"disable-dev-shm-usage", chromeFlag{"disable-dev-shm-usage", true}, false},
+		{"--disable-dev-shm-usage", chromeFlag{"disable-dev-shm-usage", true}, false},
+		{"mute-audio=true", chromeFlag{"mute-audio", true}, false},
+		{"no-sandbox=false", chromeFlag{"no-sandbox", false}, false},
+		{"lang=de-DE", chromeFlag{"lang", "de-DE"}, false},
+		{"--window-size=1280,720", chromeFlag{"window-size", "1280,720"}, false},
+		{"js-flags=--max-old-space-size=4096", chromeFlag{"js-flags", "--max-old-space-size=4096"}, false},
+		{"", chromeFlag{}, true},
+		{"--=value", chromeFlag{}, true},
+		{"two words", chromeFlag{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := parseChromeFlag(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseChromeFlag(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseChromeFlag(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateHostResolverRules(t *testing.T) {
+	tests := []struct {
+		rules   string
+		wantErr bool
+	}{
+		{"MAP www.skool.com 203.0.113.7", false},
+		{"MAP *.skool.com 203.0.113.7, EXCLUDE assets.skool.com", false},
+		{"map * ~NOTFOUND", false},
+		{"MAP www.skool.com", true},
+		{"EXCLUDE", true},
+		{"REWRITE a b", true},
+		{"MAP a b,", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rules, func(t *testing.T) {
+			err := validateHostResolverRules(tt.rules)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateHostResolverRules(%q) error = %v, wantErr %v", tt.rules, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBrowserAllocatorOptions(t *testing.T) {
+	config := Config{
+		Headless:          true,
+		HostResolverRules: "MAP www.skool.com 203.0.113.7",
+		ChromeFlags:       []string{"lang=de-DE", "disable-dev-shm-usage"},
+		NoChromeFlags:     []string{"--no-sandbox"},
+	}
+
+	// Record the switches instead of building real options
+	var got []chromeFlag
+	record := func(name string, value interface{}) chromedp.ExecAllocatorOption {
+		got = append(got, chromeFlag{name, value})
+		return chromedp.Flag(name, value)
+	}
+	opts, err := browserAllocatorOptions(config, "/usr/bin/chromium", record)
+	if err != nil {
+		t.Fatalf("browserAllocatorOptions() error: %v", err)
+	}
+	if len(opts) <= len(got) {
+		t.Errorf("Expected chromedp's defaults and the exec path besides the switches, got %d option(s)", len(opts))
+	}
+
+	want := []chromeFlag{
+		{"headless", true},
+		{"disable-gpu", true},
+		{"no-sandbox", true},
+		{"window-size", "1920,1080"},
+		{"host-resolver-rules", "MAP www.skool.com 203.0.113.7"},
+		{"lang", "de-DE"},
+		{"disable-dev-shm-usage", true},
+		{"no-sandbox", false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Switches = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseArgs_ChromeFlagsRepeat(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	config, err := parseArgs(fs, []string{"-chrome-flag=lang=de-DE", "-chrome-flag", "mute-audio", "-no-chrome-flag=no-sandbox"})
+	if err != nil {
+		t.Fatalf("parseArgs() error: %v", err)
+	}
+	if want := []string{"lang=de-DE", "mute-audio"}; !reflect.DeepEqual(config.ChromeFlags, want) {
+		t.Errorf("ChromeFlags = %v, want %v", config.ChromeFlags, want)
+	}
+	if want := []string{"no-sandbox"}; !reflect.DeepEqual(config.NoChromeFlags, want) {
+		t.Errorf("NoChromeFlags = %v, want %v", config.NoChromeFlags, want)
+	}
+}
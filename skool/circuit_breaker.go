@@ -0,0 +1,28 @@
+package skool
+
+// circuitBreaker trips after a number of consecutive download failures, which
+// usually points at a systemic problem such as expired cookies rather than
+// individual broken videos. A threshold of 0 disables it.
+type circuitBreaker struct {
+	threshold   int
+	consecutive int
+}
+
+func newCircuitBreaker(threshold int) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold}
+}
+
+// Record registers the outcome of a download and reports whether the breaker has tripped
+func (b *circuitBreaker) Record(success bool) bool {
+	if success {
+		b.consecutive = 0
+		return false
+	}
+	b.consecutive++
+	return b.Tripped()
+}
+
+// Tripped reports whether the consecutive failure threshold has been reached
+func (b *circuitBreaker) Tripped() bool {
+	return b.threshold > 0 && b.consecutive >= b.threshold
+}
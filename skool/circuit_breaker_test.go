@@ -0,0 +1,40 @@
+package skool
+
+import "testing"
+
+func TestCircuitBreaker_TripsOnConsecutiveFailures(t *testing.T) {
+	breaker := newCircuitBreaker(3)
+
+	if breaker.Record(false) || breaker.Record(false) {
+		t.Fatal("Breaker tripped before reaching the threshold")
+	}
+	if !breaker.Record(false) {
+		t.Error("Expected breaker to trip on the third consecutive failure")
+	}
+	if !breaker.Tripped() {
+		t.Error("Expected Tripped() to stay true after tripping")
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsCount(t *testing.T) {
+	breaker := newCircuitBreaker(2)
+
+	breaker.Record(false)
+	breaker.Record(true)
+	if breaker.Record(false) {
+		t.Error("Expected a success to reset the consecutive failure count")
+	}
+	if !breaker.Record(false) {
+		t.Error("Expected breaker to trip after two new consecutive failures")
+	}
+}
+
+func TestCircuitBreaker_DisabledWithZeroThreshold(t *testing.T) {
+	breaker := newCircuitBreaker(0)
+
+	for i := 0; i < 100; i++ {
+		if breaker.Record(false) {
+			t.Fatal("Disabled breaker should never trip")
+		}
+	}
+}
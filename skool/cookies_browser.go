@@ -0,0 +1,106 @@
+package skool
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+// cookieBrowsers are the browsers yt-dlp's --cookies-from-browser can read
+var cookieBrowsers = []string{"brave", "chrome", "chromium", "edge", "firefox", "opera", "safari", "vivaldi", "whale"}
+
+// validateCookieBrowser checks the browser name of a -cookies-from-browser
+// value, which may carry yt-dlp's +KEYRING, :PROFILE and ::CONTAINER suffixes
+func validateCookieBrowser(spec string) error {
+	name := strings.ToLower(spec)
+	if i := strings.IndexAny(name, "+:"); i >= 0 {
+		name = name[:i]
+	}
+	for _, b := range cookieBrowsers {
+		if name == b {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported browser %q for -cookies-from-browser (supported: %s)", spec, strings.Join(cookieBrowsers, ", "))
+}
+
+// loadCookiesFromBrowser reads the skool.com cookies from an installed
+// browser's cookie store and stages them in a Netscape temp file that both the
+// browser and yt-dlp can use. yt-dlp does the reading, which takes care of
+// profile lookup and Chromium's encrypted cookie values on every platform.
+func loadCookiesFromBrowser(browser, ytDlpPath string) ([]*network.CookieParam, string, error) {
+	dump, err := os.CreateTemp("", "cookies-browser-*.txt")
+	if err != nil {
+		return nil, "", err
+	}
+	dumpPath := dump.Name()
+	_ = dump.Close()
+	// The dump holds every cookie of the browser, not just Skool's
+	defer func() {
+		_ = os.Remove(dumpPath)
+	}()
+
+	// yt-dlp only writes the cookie jar once it has processed a URL, so point it
+	// at the Skool home page and skip the download
+	args := []string{
+		"--cookies-from-browser", browser,
+		"--cookies", dumpPath,
+		"--skip-download", "--quiet", "--no-warnings",
+		skoolBaseURL,
+	}
+	console.Debug("Running:", ytDlpPath, strings.Join(args, " "))
+	var stderr bytes.Buffer
+	cmd := exec.Command(ytDlpPath, args...)
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	// skool.com isn't a supported site, so a non-zero exit is expected as long
+	// as the cookies were written
+	content, err := os.ReadFile(dumpPath)
+	if err != nil || len(bytes.TrimSpace(content)) == 0 {
+		if runErr != nil {
+			return nil, "", fmt.Errorf("yt-dlp couldn't read cookies from %s: %v: %s", browser, runErr, strings.TrimSpace(stderr.String()))
+		}
+		return nil, "", fmt.Errorf("yt-dlp didn't write any cookies for %s", browser)
+	}
+
+	filtered := filterNetscapeCookies(content, isSkoolHost)
+	cookies, err := parseNetscapeCookies(filtered)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(cookies) == 0 {
+		return nil, "", fmt.Errorf("no skool.com cookies found in %s, log in to Skool in that browser first", browser)
+	}
+
+	path, err := writeTempCookiesFile(filtered, "cookies-*.txt")
+	if err != nil {
+		return nil, "", err
+	}
+	return cookies, path, nil
+}
+
+// filterNetscapeCookies keeps comment lines and the cookies whose domain
+// satisfies keep, dropping everything else
+func filterNetscapeCookies(content []byte, keep func(domain string) bool) []byte {
+	var out bytes.Buffer
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimSpace(line)
+		entry := strings.TrimPrefix(trimmed, netscapeHTTPOnlyPrefix)
+
+		if entry != trimmed || (trimmed != "" && !strings.HasPrefix(trimmed, "#")) {
+			domain, _, _ := strings.Cut(entry, "\t")
+			if !keep(domain) {
+				continue
+			}
+		}
+
+		out.WriteString(strings.TrimRight(line, "\r"))
+		out.WriteByte('\n')
+	}
+	return out.Bytes()
+}
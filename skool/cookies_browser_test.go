@@ -0,0 +1,97 @@
+package skool
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestValidateCookieBrowser(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr bool
+	}{
+		{spec: "chrome"},
+		{spec: "Firefox"},
+		{spec: "chromium:Profile 1"},
+		{spec: "chrome+gnomekeyring:Default"},
+		{spec: "firefox::Personal"},
+		{spec: "netscape", wantErr: true},
+		{spec: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			err := validateCookieBrowser(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateCookieBrowser(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFilterNetscapeCookies(t *testing.T) {
+	content := []byte("# Netscape HTTP Cookie File\n" +
+		".skool.com\tTRUE\t/\tTRUE\t0\tclient_id\ta\n" +
+		"#HttpOnly_.skool.com\tTRUE\t/\tTRUE\t0\tauth_token\tb\n" +
+		".google.com\tTRUE\t/\tTRUE\t0\tNID\tc\n" +
+		"#HttpOnly_.bank.example\tTRUE\t/\tTRUE\t0\tsession\td\n" +
+		"\n")
+
+	got := string(filterNetscapeCookies(content, isSkoolHost))
+
+	for _, want := range []string{"# Netscape HTTP Cookie File", "client_id", "#HttpOnly_.skool.com"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Filtered cookies should contain %q, got:\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"google", "bank.example"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("Filtered cookies should not contain %q, got:\n%s", unwanted, got)
+		}
+	}
+}
+
+func TestLoadCookiesFromBrowser_FakeYtDlp(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake yt-dlp is a shell script")
+	}
+
+	// Writes a cookie jar to the path following --cookies, like yt-dlp does
+	tmpDir := t.TempDir()
+	fakeYtDlp := filepath.Join(tmpDir, "yt-dlp")
+	script := `#!/bin/sh
+while [ $# -gt 0 ]; do
+  if [ "$1" = "--cookies" ]; then out="$2"; fi
+  shift
+done
+printf '# Netscape HTTP Cookie File\n#HttpOnly_.skool.com\tTRUE\t/\tTRUE\t0\tauth_token\tsecret\n.example.com\tTRUE\t/\tFALSE\t0\tother\tx\n' > "$out"
+echo "ERROR: Unsupported URL" >&2
+exit 1
+`
+	if err := os.WriteFile(fakeYtDlp, []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create fake yt-dlp: %v", err)
+	}
+
+	cookies, path, err := loadCookiesFromBrowser("chrome", fakeYtDlp)
+	if err != nil {
+		t.Fatalf("loadCookiesFromBrowser() error = %v", err)
+	}
+	defer func() {
+		_ = os.Remove(path)
+	}()
+
+	if len(cookies) != 1 || cookies[0].Name != "auth_token" || !cookies[0].HTTPOnly {
+		t.Errorf("Expected only the HttpOnly auth_token cookie, got %+v", cookies)
+	}
+
+	staged, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read staged cookies: %v", err)
+	}
+	if strings.Contains(string(staged), "example.com") {
+		t.Errorf("Staged cookies should only contain skool.com cookies, got:\n%s", staged)
+	}
+}
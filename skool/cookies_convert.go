@@ -0,0 +1,54 @@
+package skool
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// netscapeToJSONCookies converts a Netscape cookies file to the JSONCookie
+// format. Netscape files don't record sameSite, so it's left unset.
+func netscapeToJSONCookies(content []byte) ([]JSONCookie, error) {
+	params, err := parseNetscapeCookies(content)
+	if err != nil {
+		return nil, err
+	}
+
+	cookies := make([]JSONCookie, 0, len(params))
+	for _, p := range params {
+		cookies = append(cookies, jsonCookieFromParam(p))
+	}
+	return cookies, nil
+}
+
+// exportCookiesJSON rewrites the -cookies source, in either format and
+// optionally encrypted, as a JSON cookies file at dst. Cookies piped with
+// -cookies=- are read from stdin. It returns how many cookies were written.
+func exportCookiesJSON(config Config, stdin io.Reader, dst string) (int, error) {
+	content, name, err := readCookiesSource(config, stdin)
+	if err != nil {
+		return 0, fmt.Errorf("error reading cookies: %v", err)
+	}
+	content = stripBOM(content)
+
+	var cookies []JSONCookie
+	if detectCookiesFormat(name, content, config.CookiesFormat) == cookiesFormatJSON {
+		if err := json.Unmarshal(content, &cookies); err != nil {
+			return 0, fmt.Errorf("error parsing JSON cookies: %v", err)
+		}
+	} else if cookies, err = netscapeToJSONCookies(content); err != nil {
+		return 0, err
+	}
+	if len(cookies) == 0 {
+		return 0, fmt.Errorf("no cookies found in %s", config.CookiesFile)
+	}
+
+	file, err := createCookiesFile(dst)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	return len(cookies), writeJSONCookies(file, cookies)
+}
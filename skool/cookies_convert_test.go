@@ -0,0 +1,132 @@
+package skool
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNetscapeToJSONCookies(t *testing.T) {
+	content := "# Netscape HTTP Cookie File\n" +
+		".skool.com\tTRUE\t/\tTRUE\t1800000000\tauth_token\tabc\n" +
+		"#HttpOnly_.skool.com\tTRUE\t/api\tFALSE\t0\tsession\txyz\n"
+
+	got, err := netscapeToJSONCookies([]byte(content))
+	if err != nil {
+		t.Fatalf("netscapeToJSONCookies() error = %v", err)
+	}
+	want := []JSONCookie{
+		{Host: ".skool.com", Name: "auth_token", Value: "abc", Path: "/", Expiry: 1800000000, IsSecure: 1},
+		{Host: ".skool.com", Name: "session", Value: "xyz", Path: "/api", IsHttpOnly: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("netscapeToJSONCookies() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCookiesRoundTrip(t *testing.T) {
+	// sameSite is left at 0 since Netscape files can't carry it
+	original := []JSONCookie{
+		{Host: ".skool.com", Name: "auth_token", Value: "abc", Path: "/", Expiry: 1800000000, IsSecure: 1, IsHttpOnly: 1},
+		{Host: ".skool.com", Name: "client_id", Value: "a=b", Path: "/classroom", Expiry: 1700000000},
+		{Host: ".skool.com", Name: "session", Value: "xyz", Path: "/", IsSecure: 1},
+	}
+	content, err := json.Marshal(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonFile := filepath.Join(t.TempDir(), "cookies.json")
+	if err := os.WriteFile(jsonFile, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	netscapeFile, err := convertJSONToNetscapeCookies(jsonFile)
+	if err != nil {
+		t.Fatalf("convertJSONToNetscapeCookies() error = %v", err)
+	}
+	defer func() { _ = os.Remove(netscapeFile) }()
+
+	netscape, err := os.ReadFile(netscapeFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := netscapeToJSONCookies(netscape)
+	if err != nil {
+		t.Fatalf("netscapeToJSONCookies() error = %v", err)
+	}
+	// Session cookies come back dated ahead, see netscapeExpiry
+	if len(got) == len(original) {
+		if got[2].Expiry <= time.Now().Unix() {
+			t.Errorf("Expected the session cookie to be dated ahead, got expiry %d", got[2].Expiry)
+		}
+		got[2].Expiry = 0
+	}
+	if !reflect.DeepEqual(got, original) {
+		t.Errorf("Round trip changed the cookies:\n got  %+v\n want %+v", got, original)
+	}
+}
+
+func TestExportCookiesJSON(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "cookies.txt")
+	content := "# Netscape HTTP Cookie File\n.skool.com\tTRUE\t/\tTRUE\t1800000000\tauth_token\tabc\n"
+	if err := os.WriteFile(src, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := filepath.Join(dir, "cookies.json")
+	count, err := exportCookiesJSON(Config{CookiesFile: src}, nil, dst)
+	if err != nil || count != 1 {
+		t.Fatalf("exportCookiesJSON() = %d, %v, want 1 cookie", count, err)
+	}
+	if _, err := parseCookiesFile(dst); err != nil {
+		t.Errorf("Exported file can't be read back with -cookies: %v", err)
+	}
+
+	empty := filepath.Join(dir, "empty.txt")
+	if err := os.WriteFile(empty, []byte("# Netscape HTTP Cookie File\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := exportCookiesJSON(Config{CookiesFile: empty}, nil, dst); err == nil {
+		t.Error("Expected an error for a file without cookies")
+	}
+}
+
+func TestExportCookiesJSON_Sources(t *testing.T) {
+	content := "# Netscape HTTP Cookie File\n.skool.com\tTRUE\t/\tTRUE\t1800000000\tauth_token\tabc\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{"Stdin", Config{CookiesFile: stdinCookiesPath}},
+		{"URL", Config{CookiesFile: server.URL + "/cookies", CookiesAuthHeader: "Bearer secret"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := filepath.Join(t.TempDir(), "cookies.json")
+			count, err := exportCookiesJSON(tt.config, strings.NewReader(content), dst)
+			if err != nil || count != 1 {
+				t.Fatalf("exportCookiesJSON() = %d, %v, want 1 cookie", count, err)
+			}
+			if _, err := parseCookiesFile(dst); err != nil {
+				t.Errorf("Exported file can't be read back with -cookies: %v", err)
+			}
+		})
+	}
+}
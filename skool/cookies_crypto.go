@@ -0,0 +1,175 @@
+package skool
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// cookiesPasswordEnv is the environment variable read when -cookies-password isn't set
+const cookiesPasswordEnv = "SKOOL_COOKIES_PASSWORD"
+
+// encryptedCookiesMagic marks a cookies file written by -encrypt-cookies
+var encryptedCookiesMagic = []byte("SKOOLDL-ENC1\n")
+
+// scrypt parameters recommended for interactive logins (2^15 iterations)
+const (
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = 32 // AES-256
+	scryptSalt   = 16
+)
+
+// errWrongCookiesPassword is returned when decryption fails authentication
+var errWrongCookiesPassword = errors.New("wrong cookies password or the file has been tampered with")
+
+// isEncryptedCookies reports whether content was written by encryptCookies
+func isEncryptedCookies(content []byte) bool {
+	return bytes.HasPrefix(content, encryptedCookiesMagic)
+}
+
+// encryptCookies encrypts plaintext cookies with AES-256-GCM using a key
+// derived from password with scrypt. The output layout is
+// magic | salt | nonce | ciphertext, with the header authenticated as well.
+func encryptCookies(plaintext []byte, password string) ([]byte, error) {
+	if password == "" {
+		return nil, errors.New("a cookies password is required for encryption")
+	}
+
+	salt := make([]byte, scryptSalt)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	gcm, err := cookiesCipher(password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, len(encryptedCookiesMagic)+len(salt)+len(nonce))
+	header = append(header, encryptedCookiesMagic...)
+	header = append(header, salt...)
+	header = append(header, nonce...)
+
+	return gcm.Seal(header, nonce, plaintext, header), nil
+}
+
+// decryptCookies reverses encryptCookies, failing if the password is wrong or
+// any part of the file was modified
+func decryptCookies(content []byte, password string) ([]byte, error) {
+	if !isEncryptedCookies(content) {
+		return nil, errors.New("cookies file is not encrypted (create one with -encrypt-cookies)")
+	}
+	if password == "" {
+		return nil, errors.New("cookies file is encrypted, provide the password with -cookies-password or " + cookiesPasswordEnv)
+	}
+
+	rest := content[len(encryptedCookiesMagic):]
+	if len(rest) < scryptSalt {
+		return nil, errors.New("encrypted cookies file is truncated")
+	}
+	salt := rest[:scryptSalt]
+
+	gcm, err := cookiesCipher(password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	headerLen := len(encryptedCookiesMagic) + scryptSalt + gcm.NonceSize()
+	if len(content) < headerLen+gcm.Overhead() {
+		return nil, errors.New("encrypted cookies file is truncated")
+	}
+	header := content[:headerLen]
+	nonce := content[headerLen-gcm.NonceSize() : headerLen]
+
+	plaintext, err := gcm.Open(nil, nonce, content[headerLen:], header)
+	if err != nil {
+		return nil, errWrongCookiesPassword
+	}
+	return plaintext, nil
+}
+
+func cookiesCipher(password string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// readCookiesContent reads a cookies file, decrypting it in memory when a
+// password is given
+func readCookiesContent(filePath, password string) ([]byte, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCookiesContent(content, password)
+}
+
+// decodeCookiesContent decrypts cookies when a password is given, and refuses
+// encrypted ones when it isn't
+func decodeCookiesContent(content []byte, password string) ([]byte, error) {
+	if password == "" {
+		if isEncryptedCookies(content) {
+			return nil, errors.New("cookies file is encrypted, provide the password with -cookies-password or " + cookiesPasswordEnv)
+		}
+		return content, nil
+	}
+	return decryptCookies(content, password)
+}
+
+// encryptCookiesFile encrypts the plaintext cookies file at src into dst
+func encryptCookiesFile(src, dst, password string) error {
+	// Cookies behind a URL are already kept by the service, and fetching
+	// them here would skip -cookies-auth-header
+	if isCookiesURL(src) {
+		return errors.New("-encrypt-cookies needs a local cookies file, not a URL")
+	}
+
+	// Make sure we're not encrypting something that can't be used later
+	if _, err := parseCookiesFile(src); err != nil {
+		return fmt.Errorf("error parsing cookies: %v", err)
+	}
+
+	plaintext, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	if isEncryptedCookies(plaintext) {
+		return fmt.Errorf("%s is already encrypted", src)
+	}
+
+	encrypted, err := encryptCookies(plaintext, password)
+	if err != nil {
+		return err
+	}
+
+	file, err := createCookiesFile(dst)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	_, err = file.Write(encrypted)
+	return err
+}
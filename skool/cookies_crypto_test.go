@@ -0,0 +1,142 @@
+package skool
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEncryptDecryptCookies_RoundTrip(t *testing.T) {
+	plaintext := []byte(`[{"host": ".skool.com", "name": "auth_token", "value": "secret", "path": "/"}]`)
+
+	encrypted, err := encryptCookies(plaintext, "hunter2")
+	if err != nil {
+		t.Fatalf("encryptCookies() error = %v", err)
+	}
+	if !isEncryptedCookies(encrypted) {
+		t.Error("Expected encrypted output to carry the magic header")
+	}
+	if strings.Contains(string(encrypted), "secret") {
+		t.Error("Encrypted output contains the plaintext cookie value")
+	}
+
+	decrypted, err := decryptCookies(encrypted, "hunter2")
+	if err != nil {
+		t.Fatalf("decryptCookies() error = %v", err)
+	}
+	if string(decrypted) != string(plaintext) {
+		t.Errorf("decryptCookies() = %q, want %q", decrypted, plaintext)
+	}
+}
+
+func TestDecryptCookies_WrongPassword(t *testing.T) {
+	encrypted, err := encryptCookies([]byte("cookies"), "correct")
+	if err != nil {
+		t.Fatalf("encryptCookies() error = %v", err)
+	}
+
+	if _, err := decryptCookies(encrypted, "incorrect"); err != errWrongCookiesPassword {
+		t.Errorf("Expected errWrongCookiesPassword, got %v", err)
+	}
+}
+
+func TestDecryptCookies_Tampered(t *testing.T) {
+	encrypted, err := encryptCookies([]byte("cookies"), "correct")
+	if err != nil {
+		t.Fatalf("encryptCookies() error = %v", err)
+	}
+
+	// Flip a bit in the salt, which is part of the authenticated header
+	encrypted[len(encryptedCookiesMagic)] ^= 0x01
+	if _, err := decryptCookies(encrypted, "correct"); err == nil {
+		t.Error("Expected error for tampered header, got nil")
+	}
+}
+
+func TestDecryptCookies_Truncated(t *testing.T) {
+	if _, err := decryptCookies(encryptedCookiesMagic, "password"); err == nil {
+		t.Error("Expected error for truncated file, got nil")
+	}
+}
+
+func TestDecryptCookies_NotEncrypted(t *testing.T) {
+	if _, err := decryptCookies([]byte("[]"), "password"); err == nil {
+		t.Error("Expected error for plaintext content, got nil")
+	}
+}
+
+func TestReadCookiesContent_EncryptedWithoutPassword(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cookies.enc")
+	encrypted, err := encryptCookies([]byte("cookies"), "password")
+	if err != nil {
+		t.Fatalf("encryptCookies() error = %v", err)
+	}
+	if err := os.WriteFile(path, encrypted, 0600); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	if _, err := readCookiesContent(path, ""); err == nil {
+		t.Error("Expected error reading encrypted cookies without a password, got nil")
+	}
+}
+
+func TestEncryptCookiesFile_LoadCookies(t *testing.T) {
+	tmpDir := t.TempDir()
+	src := filepath.Join(tmpDir, "cookies.json")
+	dst := filepath.Join(tmpDir, "cookies.enc")
+
+	jsonContent := `[
+		{
+			"host": ".skool.com",
+			"name": "auth_token",
+			"value": "secret",
+			"path": "/",
+			"expiry": 1700000000,
+			"isSecure": 1,
+			"isHttpOnly": 1,
+			"sameSite": 0
+		}
+	]`
+	if err := os.WriteFile(src, []byte(jsonContent), 0600); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	if err := encryptCookiesFile(src, dst, "password"); err != nil {
+		t.Fatalf("encryptCookiesFile() error = %v", err)
+	}
+
+	cookies, err := loadCookies(Config{CookiesFile: dst, CookiesPassword: "password"})
+	if err != nil {
+		t.Fatalf("loadCookies() error = %v", err)
+	}
+	if len(cookies) != 1 || cookies[0].Name != "auth_token" {
+		t.Errorf("Unexpected cookies after decryption: %v", cookies)
+	}
+
+	// yt-dlp gets a decrypted Netscape temp file
+	ytDlpCookies, cleanup, err := prepareYtDlpCookies(Config{CookiesFile: dst, CookiesPassword: "password"})
+	if err != nil {
+		t.Fatalf("prepareYtDlpCookies() error = %v", err)
+	}
+	defer cleanup()
+
+	content, err := os.ReadFile(ytDlpCookies)
+	if err != nil {
+		t.Fatalf("Failed to read yt-dlp cookies: %v", err)
+	}
+	if !strings.Contains(string(content), "# Netscape HTTP Cookie File") || !strings.Contains(string(content), "auth_token") {
+		t.Errorf("Expected Netscape cookies for yt-dlp, got %q", content)
+	}
+}
+
+func TestEncryptCookiesFile_RejectsURL(t *testing.T) {
+	dst := filepath.Join(t.TempDir(), "cookies.enc")
+	err := encryptCookiesFile("https://secrets.example.com/cookies.json", dst, "password")
+	if err == nil || !strings.Contains(err.Error(), "not a URL") {
+		t.Errorf("encryptCookiesFile() with a URL = %v, want an error", err)
+	}
+	if _, statErr := os.Stat(dst); statErr == nil {
+		t.Error("Expected no encrypted file for a URL source")
+	}
+}
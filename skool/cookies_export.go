@@ -0,0 +1,245 @@
+package skool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+// Cookie export formats, selected by the extension of the export path
+const (
+	exportFormatJSON       = "json"
+	exportFormatFirefoxSQL = "sql"
+)
+
+// firefoxSessionCookieLifetime is the expiry given to session cookies in a
+// Firefox export, since cookies.sqlite has no notion of session cookies
+const firefoxSessionCookieLifetime = 24 * time.Hour
+
+// Firefox's scheme map bits (nsICookie::SCHEME_HTTP / SCHEME_HTTPS)
+const (
+	firefoxSchemeHTTP  = 1
+	firefoxSchemeHTTPS = 2
+)
+
+// firefoxCookieRow mirrors a row of the moz_cookies table in Firefox's cookies.sqlite
+type firefoxCookieRow struct {
+	OriginAttributes string
+	Name             string
+	Value            string
+	Host             string
+	Path             string
+	Expiry           int64 // seconds since epoch
+	LastAccessed     int64 // microseconds since epoch
+	CreationTime     int64 // microseconds since epoch
+	IsSecure         int
+	IsHttpOnly       int
+	SameSite         int
+	RawSameSite      int
+	SchemeMap        int
+}
+
+// cookieExportFormat determines the export format from the file extension
+func cookieExportFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return exportFormatJSON, nil
+	case ".sql":
+		return exportFormatFirefoxSQL, nil
+	default:
+		return "", fmt.Errorf("unsupported cookie export format for %q (use .json or .sql)", path)
+	}
+}
+
+// exportBrowserCookies reads the skool.com cookies from the running browser and
+// writes them to path in the format implied by its extension
+func exportBrowserCookies(ctx context.Context, path string) error {
+	cookies, err := browserCookies(ctx)
+	if err != nil {
+		return err
+	}
+	return exportCookies(path, cookies)
+}
+
+// saveBrowserCookies writes the browser's skool.com cookies to path in the
+// JSON format accepted by -cookies, returning how many were saved
+func saveBrowserCookies(ctx context.Context, path string) (int, error) {
+	cookies, err := browserCookies(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if !hasAuthCookie(cookies) {
+		console.Warning("No skool.com auth_token cookie found, the saved cookies may not authenticate future runs")
+	}
+
+	file, err := createCookiesFile(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	return len(cookies), writeJSONCookies(file, cookies)
+}
+
+// browserCookies returns the cookies the browser would send to skool.com
+func browserCookies(ctx context.Context) ([]JSONCookie, error) {
+	var browserCookies []*network.Cookie
+	if err := chromedp.Run(ctx, chromedp.ActionFunc(func(ctx context.Context) error {
+		var err error
+		browserCookies, err = network.GetCookies().WithURLs([]string{skoolBaseURL}).Do(ctx)
+		return err
+	})); err != nil {
+		return nil, fmt.Errorf("error reading browser cookies: %v", err)
+	}
+
+	cookies := make([]JSONCookie, 0, len(browserCookies))
+	for _, c := range browserCookies {
+		cookies = append(cookies, jsonCookieFromBrowser(c))
+	}
+	return cookies, nil
+}
+
+// exportCookies writes cookies to path as JSON or as a Firefox SQL script
+func exportCookies(path string, cookies []JSONCookie) error {
+	format, err := cookieExportFormat(path)
+	if err != nil {
+		return err
+	}
+
+	file, err := createCookiesFile(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if format == exportFormatFirefoxSQL {
+		return writeFirefoxCookiesSQL(file, firefoxCookieRows(cookies, time.Now()))
+	}
+	return writeJSONCookies(file, cookies)
+}
+
+// createCookiesFile creates a file readable only by the current user, since
+// cookies grant access to the account
+func createCookiesFile(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+}
+
+// jsonCookieFromBrowser converts a cookie reported by the browser into the
+// JSONCookie format that parseJSONCookies reads
+func jsonCookieFromBrowser(c *network.Cookie) JSONCookie {
+	cookie := JSONCookie{
+		Host:  c.Domain,
+		Name:  c.Name,
+		Value: c.Value,
+		Path:  c.Path,
+	}
+
+	if !c.Session && c.Expires > 0 {
+		cookie.Expiry = int64(c.Expires)
+	}
+	if c.Secure {
+		cookie.IsSecure = 1
+	}
+	if c.HTTPOnly {
+		cookie.IsHttpOnly = 1
+	}
+
+	// Inverse of the SameSite mapping in jsonSameSite
+	switch c.SameSite {
+	case network.CookieSameSiteLax:
+		cookie.SameSite = 1
+	case network.CookieSameSiteStrict:
+		cookie.SameSite = 2
+	case network.CookieSameSiteNone:
+		cookie.SameSite = 3
+	}
+
+	return cookie
+}
+
+func writeJSONCookies(w io.Writer, cookies []JSONCookie) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(cookies)
+}
+
+// firefoxCookieRows builds moz_cookies rows from cookies, using now as the
+// creation and last-access time
+func firefoxCookieRows(cookies []JSONCookie, now time.Time) []firefoxCookieRow {
+	rows := make([]firefoxCookieRow, 0, len(cookies))
+	for _, c := range cookies {
+		expiry := c.Expiry
+		if expiry <= 0 {
+			expiry = now.Add(firefoxSessionCookieLifetime).Unix()
+		}
+
+		// Firefox uses 0 = None, 1 = Lax, 2 = Strict
+		sameSite := 0
+		switch c.SameSite {
+		case 1, 2:
+			sameSite = c.SameSite
+		}
+
+		schemeMap := firefoxSchemeHTTP | firefoxSchemeHTTPS
+		if c.IsSecure == 1 {
+			schemeMap = firefoxSchemeHTTPS
+		}
+
+		rows = append(rows, firefoxCookieRow{
+			Name:         c.Name,
+			Value:        c.Value,
+			Host:         c.Host,
+			Path:         c.Path,
+			Expiry:       expiry,
+			LastAccessed: now.UnixMicro(),
+			CreationTime: now.UnixMicro(),
+			IsSecure:     c.IsSecure,
+			IsHttpOnly:   c.IsHttpOnly,
+			SameSite:     sameSite,
+			RawSameSite:  sameSite,
+			SchemeMap:    schemeMap,
+		})
+	}
+	return rows
+}
+
+// writeFirefoxCookiesSQL writes rows as an SQL script that can be imported into
+// Firefox's cookies.sqlite with: sqlite3 cookies.sqlite < cookies.sql
+func writeFirefoxCookiesSQL(w io.Writer, rows []firefoxCookieRow) error {
+	if _, err := fmt.Fprintln(w, `-- Firefox cookies generated by skool-downloader
+-- Import with: sqlite3 cookies.sqlite < this-file.sql (close Firefox first)
+BEGIN TRANSACTION;
+CREATE TABLE IF NOT EXISTS moz_cookies (id INTEGER PRIMARY KEY, originAttributes TEXT NOT NULL DEFAULT '', name TEXT, value TEXT, host TEXT, path TEXT, expiry INTEGER, lastAccessed INTEGER, creationTime INTEGER, isSecure INTEGER, isHttpOnly INTEGER, inBrowserElement INTEGER DEFAULT 0, sameSite INTEGER DEFAULT 0, rawSameSite INTEGER DEFAULT 0, schemeMap INTEGER DEFAULT 0, CONSTRAINT moz_uniqueid UNIQUE (name, host, path, originAttributes));`); err != nil {
+		return err
+	}
+
+	for _, r := range rows {
+		if _, err := fmt.Fprintf(w,
+			"INSERT OR REPLACE INTO moz_cookies (originAttributes, name, value, host, path, expiry, lastAccessed, creationTime, isSecure, isHttpOnly, sameSite, rawSameSite, schemeMap) VALUES (%s, %s, %s, %s, %s, %d, %d, %d, %d, %d, %d, %d, %d);\n",
+			sqlQuote(r.OriginAttributes), sqlQuote(r.Name), sqlQuote(r.Value), sqlQuote(r.Host), sqlQuote(r.Path),
+			r.Expiry, r.LastAccessed, r.CreationTime, r.IsSecure, r.IsHttpOnly, r.SameSite, r.RawSameSite, r.SchemeMap); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintln(w, "COMMIT;")
+	return err
+}
+
+// sqlQuote returns s as an SQL string literal
+func sqlQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
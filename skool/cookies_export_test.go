@@ -0,0 +1,176 @@
+package skool
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+func TestCookieExportFormat(t *testing.T) {
+	tests := []struct {
+		path      string
+		expected  string
+		shouldErr bool
+	}{
+		{path: "cookies.json", expected: exportFormatJSON},
+		{path: "out/Cookies.JSON", expected: exportFormatJSON},
+		{path: "cookies.sql", expected: exportFormatFirefoxSQL},
+		{path: "cookies.txt", shouldErr: true},
+		{path: "cookies", shouldErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			format, err := cookieExportFormat(tt.path)
+			if tt.shouldErr {
+				if err == nil {
+					t.Errorf("cookieExportFormat(%q) expected error, got nil", tt.path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("cookieExportFormat(%q) unexpected error: %v", tt.path, err)
+			}
+			if format != tt.expected {
+				t.Errorf("cookieExportFormat(%q) = %q, want %q", tt.path, format, tt.expected)
+			}
+		})
+	}
+}
+
+func TestJSONCookieFromBrowser_RoundTrip(t *testing.T) {
+	browserCookie := &network.Cookie{
+		Name:     "auth_token",
+		Value:    "secret",
+		Domain:   ".skool.com",
+		Path:     "/",
+		Expires:  1800000000,
+		HTTPOnly: true,
+		Secure:   true,
+		SameSite: network.CookieSameSiteLax,
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSONCookies(&buf, []JSONCookie{jsonCookieFromBrowser(browserCookie)}); err != nil {
+		t.Fatalf("writeJSONCookies() error = %v", err)
+	}
+
+	cookies, err := parseJSONCookies(buf.Bytes(), sameSiteZeroUnset)
+	if err != nil {
+		t.Fatalf("parseJSONCookies() error = %v", err)
+	}
+	if len(cookies) != 1 {
+		t.Fatalf("Expected 1 cookie, got %d", len(cookies))
+	}
+
+	c := cookies[0]
+	if c.Name != "auth_token" || c.Value != "secret" || c.Path != "/" {
+		t.Errorf("Unexpected cookie after round trip: %+v", c)
+	}
+	if !c.Secure || !c.HTTPOnly {
+		t.Error("Expected Secure and HTTPOnly to survive the round trip")
+	}
+	if c.SameSite != network.CookieSameSiteLax {
+		t.Errorf("Expected SameSite Lax, got %v", c.SameSite)
+	}
+	if c.Expires == nil || time.Time(*c.Expires).Unix() != 1800000000 {
+		t.Errorf("Expected expiry 1800000000, got %v", c.Expires)
+	}
+}
+
+func TestJSONCookieFromBrowser_Session(t *testing.T) {
+	cookie := jsonCookieFromBrowser(&network.Cookie{Name: "session", Expires: -1, Session: true})
+	if cookie.Expiry != 0 {
+		t.Errorf("Expected session cookie expiry 0, got %d", cookie.Expiry)
+	}
+}
+
+func TestFirefoxCookieRows(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cookies := []JSONCookie{
+		{Host: ".skool.com", Name: "auth_token", Value: "secret", Path: "/", Expiry: 1800000000, IsSecure: 1, IsHttpOnly: 1, SameSite: 1},
+		{Host: "www.skool.com", Name: "session", Value: "abc", Path: "/", SameSite: 3},
+	}
+
+	rows := firefoxCookieRows(cookies, now)
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+
+	if rows[0].Expiry != 1800000000 {
+		t.Errorf("Expected expiry 1800000000, got %d", rows[0].Expiry)
+	}
+	if rows[0].IsSecure != 1 || rows[0].IsHttpOnly != 1 {
+		t.Error("Expected secure and httpOnly flags to be kept")
+	}
+	if rows[0].SameSite != 1 {
+		t.Errorf("Expected Firefox sameSite Lax (1), got %d", rows[0].SameSite)
+	}
+	if rows[0].SchemeMap != firefoxSchemeHTTPS {
+		t.Errorf("Expected HTTPS-only scheme map for secure cookie, got %d", rows[0].SchemeMap)
+	}
+	if rows[0].CreationTime != now.UnixMicro() {
+		t.Errorf("Expected creation time in microseconds, got %d", rows[0].CreationTime)
+	}
+
+	if rows[1].Expiry != now.Add(firefoxSessionCookieLifetime).Unix() {
+		t.Errorf("Expected session cookie to get a %v lifetime, got expiry %d", firefoxSessionCookieLifetime, rows[1].Expiry)
+	}
+	if rows[1].SameSite != 0 {
+		t.Errorf("Expected SameSite None to map to Firefox 0, got %d", rows[1].SameSite)
+	}
+	if rows[1].SchemeMap != firefoxSchemeHTTP|firefoxSchemeHTTPS {
+		t.Errorf("Expected HTTP+HTTPS scheme map for non-secure cookie, got %d", rows[1].SchemeMap)
+	}
+}
+
+func TestWriteFirefoxCookiesSQL(t *testing.T) {
+	rows := []firefoxCookieRow{{Name: "quote", Value: "it's", Host: ".skool.com", Path: "/", Expiry: 1800000000}}
+
+	var buf bytes.Buffer
+	if err := writeFirefoxCookiesSQL(&buf, rows); err != nil {
+		t.Fatalf("writeFirefoxCookiesSQL() error = %v", err)
+	}
+
+	sql := buf.String()
+	if !strings.Contains(sql, "CREATE TABLE IF NOT EXISTS moz_cookies") {
+		t.Error("Missing moz_cookies table definition")
+	}
+	if !strings.Contains(sql, "'it''s'") {
+		t.Error("Expected single quotes in values to be escaped")
+	}
+	if !strings.HasSuffix(strings.TrimSpace(sql), "COMMIT;") {
+		t.Error("Expected script to end with COMMIT")
+	}
+}
+
+func TestExportCookies_JSONFilePermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cookies.json")
+	cookies := []JSONCookie{{Host: ".skool.com", Name: "auth_token", Value: "secret", Path: "/", IsSecure: 1}}
+
+	if err := exportCookies(path, cookies); err != nil {
+		t.Fatalf("exportCookies() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Failed to stat exported file: %v", err)
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("Expected cookie file mode 0600, got %v", info.Mode().Perm())
+	}
+
+	parsed, err := parseCookiesFile(path)
+	if err != nil {
+		t.Fatalf("parseCookiesFile() error = %v", err)
+	}
+	if len(parsed) != 1 || parsed[0].Name != "auth_token" {
+		t.Errorf("Unexpected cookies after export: %v", parsed)
+	}
+}
@@ -0,0 +1,107 @@
+package skool
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+// Cookie file formats accepted by -cookies-format
+const (
+	cookiesFormatAuto     = "auto"
+	cookiesFormatJSON     = "json"
+	cookiesFormatNetscape = "netscape"
+)
+
+// How a JSON sameSite of 0 is read, selected with -cookies-samesite-zero
+const (
+	sameSiteZeroUnset = "unset" // no attribute, the browser's default applies (this tool's own exports)
+	sameSiteZeroNone  = "none"  // SameSite=None, as in Firefox's numbering where 0 is None
+)
+
+// utf8BOM is written at the start of files by some Windows editors and exporters
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// validateCookiesFormat checks the -cookies-format value, where empty means auto
+func validateCookiesFormat(format string) error {
+	switch format {
+	case "", cookiesFormatAuto, cookiesFormatJSON, cookiesFormatNetscape:
+		return nil
+	default:
+		return fmt.Errorf("unknown -cookies-format %q (use %s, %s or %s)", format, cookiesFormatAuto, cookiesFormatJSON, cookiesFormatNetscape)
+	}
+}
+
+// stripBOM removes a leading UTF-8 byte order mark
+func stripBOM(content []byte) []byte {
+	return bytes.TrimPrefix(content, utf8BOM)
+}
+
+// detectCookiesFormat returns the format of a cookies file. A forced format
+// wins; otherwise a .json or .txt extension decides, and any other name
+// (including none) is sniffed from the content.
+func detectCookiesFormat(name string, content []byte, format string) string {
+	switch format {
+	case cookiesFormatJSON, cookiesFormatNetscape:
+		return format
+	}
+
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return cookiesFormatJSON
+	case ".txt":
+		return cookiesFormatNetscape
+	}
+	if looksLikeJSONCookies(content) {
+		return cookiesFormatJSON
+	}
+	return cookiesFormatNetscape
+}
+
+// validateSameSiteZero checks the -cookies-samesite-zero value, where empty means unset
+func validateSameSiteZero(zero string) error {
+	switch zero {
+	case "", sameSiteZeroUnset, sameSiteZeroNone:
+		return nil
+	default:
+		return fmt.Errorf("unknown -cookies-samesite-zero %q (use %s or %s)", zero, sameSiteZeroUnset, sameSiteZeroNone)
+	}
+}
+
+// validateCookiesFlags checks the flags that say how -cookies is read. The
+// cookie utility modes run before validateConfig, so they check these first.
+func validateCookiesFlags(config Config) error {
+	if err := validateCookiesFormat(config.CookiesFormat); err != nil {
+		return err
+	}
+	return validateSameSiteZero(config.SameSiteZero)
+}
+
+// jsonSameSite maps the numeric sameSite of a JSON cookie to CDP:
+//
+//	0: unset, or None when zero is sameSiteZeroNone
+//	1: Lax
+//	2: Strict
+//	3: None
+//	anything else: unset, so the browser's default applies
+func jsonSameSite(value int, zero string) network.CookieSameSite {
+	switch value {
+	case 0:
+		if zero == sameSiteZeroNone {
+			return network.CookieSameSiteNone
+		}
+		return ""
+	case 1:
+		return network.CookieSameSiteLax
+	case 2:
+		return network.CookieSameSiteStrict
+	case 3:
+		return network.CookieSameSiteNone
+	default:
+		console.Debugf("Ignoring unknown cookie sameSite value %d", value)
+		return ""
+	}
+}
@@ -0,0 +1,145 @@
+package skool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+const (
+	formatTestJSON     = `[{"host": ".skool.com", "name": "auth_token", "value": "abc", "path": "/", "expiry": 1700000000}]`
+	formatTestNetscape = "# Netscape HTTP Cookie File\n.skool.com\tTRUE\t/\tTRUE\t1700000000\tauth_token\tabc\n"
+)
+
+func TestDetectCookiesFormat(t *testing.T) {
+	bom := string(utf8BOM)
+
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		format   string
+		expected string
+	}{
+		{"JSON extension", "cookies.json", formatTestNetscape, cookiesFormatAuto, cookiesFormatJSON},
+		{"TXT extension", "cookies.txt", formatTestJSON, cookiesFormatAuto, cookiesFormatNetscape},
+		{"Sniffed JSON", "cookies.cookies", formatTestJSON, cookiesFormatAuto, cookiesFormatJSON},
+		{"Sniffed JSON with BOM and whitespace", "cookies.cookies", bom + "\n  " + formatTestJSON + "\n", cookiesFormatAuto, cookiesFormatJSON},
+		{"Sniffed Netscape without extension", "cookies", formatTestNetscape, cookiesFormatAuto, cookiesFormatNetscape},
+		{"Empty format means auto", "cookies", formatTestJSON, "", cookiesFormatJSON},
+		{"Forced Netscape overrides extension", "cookies.json", formatTestNetscape, cookiesFormatNetscape, cookiesFormatNetscape},
+		{"Forced JSON overrides extension", "cookies.txt", formatTestJSON, cookiesFormatJSON, cookiesFormatJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectCookiesFormat(tt.file, []byte(tt.content), tt.format); got != tt.expected {
+				t.Errorf("detectCookiesFormat() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseCookiesFile_BOMPrefixedJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "skool.cookies")
+	content := append(append([]byte{}, utf8BOM...), []byte("\n\t"+formatTestJSON)...)
+	if err := os.WriteFile(path, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cookies, err := parseCookiesFile(path)
+	if err != nil {
+		t.Fatalf("parseCookiesFile() error = %v", err)
+	}
+	if len(cookies) != 1 || cookies[0].Name != "auth_token" {
+		t.Errorf("Unexpected cookies: %+v", cookies)
+	}
+}
+
+func TestParseCookiesFile_ExtensionlessNetscape(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cookies")
+	if err := os.WriteFile(path, []byte(formatTestNetscape), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cookies, err := parseCookiesFile(path)
+	if err != nil {
+		t.Fatalf("parseCookiesFile() error = %v", err)
+	}
+	if len(cookies) != 1 || cookies[0].Name != "auth_token" {
+		t.Errorf("Unexpected cookies: %+v", cookies)
+	}
+}
+
+func TestLoadCookies_ForcedFormat(t *testing.T) {
+	// Netscape content behind a misleading .json extension
+	path := filepath.Join(t.TempDir(), "cookies.json")
+	if err := os.WriteFile(path, []byte(formatTestNetscape), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loadCookies(Config{CookiesFile: path, CookiesFormat: cookiesFormatAuto}); err == nil {
+		t.Error("Expected auto-detection to fail on Netscape content in a .json file")
+	}
+
+	cookies, err := loadCookies(Config{CookiesFile: path, CookiesFormat: cookiesFormatNetscape})
+	if err != nil {
+		t.Fatalf("loadCookies() error = %v", err)
+	}
+	if len(cookies) != 1 {
+		t.Errorf("Expected 1 cookie, got %d", len(cookies))
+	}
+}
+
+func TestValidateCookiesFormat(t *testing.T) {
+	for _, format := range []string{"", cookiesFormatAuto, cookiesFormatJSON, cookiesFormatNetscape} {
+		if err := validateCookiesFormat(format); err != nil {
+			t.Errorf("validateCookiesFormat(%q) returned %v", format, err)
+		}
+	}
+	if err := validateCookiesFormat("yaml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestJSONSameSite(t *testing.T) {
+	tests := []struct {
+		value    int
+		zero     string
+		expected network.CookieSameSite
+	}{
+		{0, sameSiteZeroUnset, ""},
+		{0, "", ""},
+		{0, sameSiteZeroNone, network.CookieSameSiteNone},
+		{1, sameSiteZeroUnset, network.CookieSameSiteLax},
+		{2, sameSiteZeroUnset, network.CookieSameSiteStrict},
+		{3, sameSiteZeroUnset, network.CookieSameSiteNone},
+		{3, sameSiteZeroNone, network.CookieSameSiteNone},
+		{4, sameSiteZeroNone, ""},
+		{-1, sameSiteZeroUnset, ""},
+		{256, sameSiteZeroUnset, ""},
+	}
+
+	for _, tt := range tests {
+		if got := jsonSameSite(tt.value, tt.zero); got != tt.expected {
+			t.Errorf("jsonSameSite(%d, %q) = %q, want %q", tt.value, tt.zero, got, tt.expected)
+		}
+	}
+}
+
+func TestLoadCookies_SameSiteZero(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cookies.json")
+	if err := os.WriteFile(path, []byte(formatTestJSON), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cookies, err := loadCookies(Config{CookiesFile: path, SameSiteZero: sameSiteZeroNone})
+	if err != nil {
+		t.Fatalf("loadCookies() error = %v", err)
+	}
+	if cookies[0].SameSite != network.CookieSameSiteNone {
+		t.Errorf("Expected SameSite None with -cookies-samesite-zero=none, got %q", cookies[0].SameSite)
+	}
+}
@@ -0,0 +1,124 @@
+package skool
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+// splitCookiesPaths returns the files of a comma separated -cookies list, or
+// nil when value is a single file. A path that exists as-is is never split,
+// so file names with commas keep working.
+func splitCookiesPaths(value string) []string {
+	if !strings.Contains(value, ",") {
+		return nil
+	}
+	if _, err := os.Stat(value); err == nil {
+		return nil
+	}
+	var paths []string
+	for _, path := range strings.Split(value, ",") {
+		paths = append(paths, strings.TrimSpace(path))
+	}
+	return paths
+}
+
+// validateCookiesPaths checks a -cookies list, which can only name local files
+func validateCookiesPaths(paths []string) error {
+	for _, path := range paths {
+		switch {
+		case path == "":
+			return fmt.Errorf("empty path in -cookies list")
+		case path == stdinCookiesPath, isCookiesURL(path):
+			return fmt.Errorf("-cookies lists can only contain files, not %q", path)
+		}
+	}
+	return nil
+}
+
+// cookieKey identifies a cookie by name and domain, ignoring a leading dot
+func cookieKey(c *network.CookieParam) string {
+	return c.Name + "\x00" + strings.ToLower(strings.TrimPrefix(c.Domain, "."))
+}
+
+// mergeCookies combines cookie lists in order. A cookie with the same name
+// and domain as an earlier one replaces it, keeping the earlier position.
+func mergeCookies(lists ...[]*network.CookieParam) []*network.CookieParam {
+	var merged []*network.CookieParam
+	index := make(map[string]int)
+	for _, list := range lists {
+		for _, c := range list {
+			key := cookieKey(c)
+			if i, ok := index[key]; ok {
+				merged[i] = c
+				continue
+			}
+			index[key] = len(merged)
+			merged = append(merged, c)
+		}
+	}
+	return merged
+}
+
+// jsonCookieFromParam converts a parsed cookie back to the JSONCookie format
+func jsonCookieFromParam(p *network.CookieParam) JSONCookie {
+	cookie := JSONCookie{
+		Host:  p.Domain,
+		Name:  p.Name,
+		Value: p.Value,
+		Path:  p.Path,
+	}
+	if p.Expires != nil {
+		cookie.Expiry = p.Expires.Time().Unix()
+	}
+	if p.Secure {
+		cookie.IsSecure = 1
+	}
+	if p.HTTPOnly {
+		cookie.IsHttpOnly = 1
+	}
+
+	// Inverse of the SameSite mapping in jsonSameSite
+	switch p.SameSite {
+	case network.CookieSameSiteLax:
+		cookie.SameSite = 1
+	case network.CookieSameSiteStrict:
+		cookie.SameSite = 2
+	case network.CookieSameSiteNone:
+		cookie.SameSite = 3
+	}
+	return cookie
+}
+
+// stageMergedCookies loads each cookies file with the -cookies-format and
+// -cookies-password settings, merges them with mergeCookies and writes the
+// result to a temporary JSON cookies file. It returns the file and the
+// number of cookies in it.
+func stageMergedCookies(paths []string, config Config) (string, int, error) {
+	var lists [][]*network.CookieParam
+	for _, path := range paths {
+		config.CookiesFile = path
+		cookies, err := loadCookies(config)
+		if err != nil {
+			return "", 0, fmt.Errorf("%s: %v", path, err)
+		}
+		console.Debugf("Read %d cookie(s) from %s", len(cookies), path)
+		lists = append(lists, cookies)
+	}
+
+	merged := mergeCookies(lists...)
+	cookies := make([]JSONCookie, 0, len(merged))
+	for _, c := range merged {
+		cookies = append(cookies, jsonCookieFromParam(c))
+	}
+
+	var buf bytes.Buffer
+	if err := writeJSONCookies(&buf, cookies); err != nil {
+		return "", 0, err
+	}
+	path, err := writeTempCookiesFile(buf.Bytes(), "cookies-merged-*.json")
+	return path, len(cookies), err
+}
@@ -0,0 +1,94 @@
+package skool
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+func TestMergeCookies(t *testing.T) {
+	first := []*network.CookieParam{
+		{Name: "auth_token", Value: "old", Domain: ".skool.com"},
+		{Name: "client_id", Value: "a", Domain: "www.skool.com"},
+	}
+	second := []*network.CookieParam{
+		{Name: "loom_session", Value: "l", Domain: ".loom.com"},
+		{Name: "auth_token", Value: "new", Domain: "skool.com"},
+		{Name: "client_id", Value: "b", Domain: "other.skool.com"},
+	}
+
+	var got []string
+	for _, c := range mergeCookies(first, second) {
+		got = append(got, c.Name+"="+c.Value+"@"+c.Domain)
+	}
+	want := []string{
+		"auth_token=new@skool.com",
+		"client_id=a@www.skool.com",
+		"loom_session=l@.loom.com",
+		"client_id=b@other.skool.com",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeCookies() = %v, want %v", got, want)
+	}
+}
+
+func TestSplitCookiesPaths(t *testing.T) {
+	if got := splitCookiesPaths("cookies.json"); got != nil {
+		t.Errorf("splitCookiesPaths() of a single file = %v, want nil", got)
+	}
+	if got := splitCookiesPaths("a.json, b.txt"); !reflect.DeepEqual(got, []string{"a.json", "b.txt"}) {
+		t.Errorf("splitCookiesPaths() = %v", got)
+	}
+
+	withComma := filepath.Join(t.TempDir(), "cookies,backup.json")
+	if err := os.WriteFile(withComma, []byte("[]"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got := splitCookiesPaths(withComma); got != nil {
+		t.Errorf("Expected an existing file with a comma not to be split, got %v", got)
+	}
+
+	if err := validateCookiesPaths([]string{"a.json", "-"}); err == nil {
+		t.Error("Expected an error for stdin in a -cookies list")
+	}
+	if err := validateCookiesPaths([]string{"a.json", ""}); err == nil {
+		t.Error("Expected an error for an empty path in a -cookies list")
+	}
+}
+
+func TestStageMergedCookies(t *testing.T) {
+	dir := t.TempDir()
+	skool := filepath.Join(dir, "skool.json")
+	loom := filepath.Join(dir, "loom.txt")
+	if err := os.WriteFile(skool, []byte(`[{"host": ".skool.com", "name": "auth_token", "value": "abc", "path": "/", "expiry": 4102444800, "isSecure": 1, "isHttpOnly": 1, "sameSite": 1}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(loom, []byte("# Netscape HTTP Cookie File\n.loom.com\tTRUE\t/\tTRUE\t4102444800\tconnect.sid\txyz\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	path, count, err := stageMergedCookies([]string{skool, loom}, Config{})
+	if err != nil {
+		t.Fatalf("stageMergedCookies() error = %v", err)
+	}
+	defer func() {
+		_ = os.Remove(path)
+	}()
+	if count != 2 {
+		t.Errorf("Expected 2 merged cookies, got %d", count)
+	}
+
+	cookies, err := parseCookiesFile(path)
+	if err != nil {
+		t.Fatalf("parseCookiesFile() of the merged file error = %v", err)
+	}
+	if len(cookies) != 2 || cookies[0].Name != "auth_token" || cookies[1].Name != "connect.sid" {
+		t.Fatalf("Unexpected merged cookies: %+v", cookies)
+	}
+	if c := cookies[0]; !c.Secure || !c.HTTPOnly || c.SameSite != network.CookieSameSiteLax {
+		t.Errorf("Expected the auth_token attributes to survive the merge, got %+v", c)
+	}
+}
@@ -0,0 +1,68 @@
+package skool
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// cookiesAuthEnv holds the -cookies-auth-header value, so tokens stay out of shell history
+	cookiesAuthEnv      = "SKOOL_COOKIES_AUTH"
+	cookiesFetchTimeout = 30 * time.Second
+)
+
+// isCookiesURL reports whether -cookies points at an http or https endpoint
+// instead of a local file
+func isCookiesURL(path string) bool {
+	lower := strings.ToLower(path)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://")
+}
+
+// fetchCookies downloads cookies from target, sending authHeader as the
+// Authorization header when it's set (e.g. "Bearer <token>" or "Basic <base64>")
+func fetchCookies(target, authHeader string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+
+	client := &http.Client{Timeout: cookiesFetchTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("cookies endpoint returned %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// stageRemoteCookies fetches cookies from target into a temp file, like
+// stageStdinCookies, so they're fetched once and the browser and yt-dlp read
+// the same copy
+func stageRemoteCookies(target, authHeader string) (string, error) {
+	content, err := fetchCookies(target, authHeader)
+	if err != nil {
+		return "", err
+	}
+	if len(strings.TrimSpace(string(content))) == 0 {
+		return "", errors.New("cookies endpoint returned an empty body")
+	}
+
+	pattern := "cookies-remote-*.txt"
+	if looksLikeJSONCookies(content) {
+		pattern = "cookies-remote-*.json"
+	}
+	return writeTempCookiesFile(content, pattern)
+}
@@ -0,0 +1,72 @@
+package skool
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+const remoteCookiesJSON = `[{"host": ".skool.com", "name": "auth_token", "value": "abc", "path": "/", "expiry": 1700000000}]`
+
+func TestParseCookiesFile_URL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(remoteCookiesJSON))
+	}))
+	defer server.Close()
+
+	cookies, err := parseCookiesFile(server.URL + "/cookies")
+	if err != nil {
+		t.Fatalf("parseCookiesFile() error = %v", err)
+	}
+	if len(cookies) != 1 || cookies[0].Name != "auth_token" || cookies[0].Value != "abc" {
+		t.Errorf("Expected the auth_token cookie, got %+v", cookies)
+	}
+}
+
+func TestStageRemoteCookies_AuthHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(remoteCookiesJSON))
+	}))
+	defer server.Close()
+
+	if _, err := stageRemoteCookies(server.URL, ""); err == nil {
+		t.Error("Expected an error without the Authorization header")
+	}
+
+	path, err := stageRemoteCookies(server.URL, "Bearer secret")
+	if err != nil {
+		t.Fatalf("stageRemoteCookies() error = %v", err)
+	}
+	defer func() {
+		_ = os.Remove(path)
+	}()
+
+	cookies, err := parseCookiesFile(path)
+	if err != nil {
+		t.Fatalf("parseCookiesFile() error = %v", err)
+	}
+	if len(cookies) != 1 || cookies[0].Name != "auth_token" {
+		t.Errorf("Expected the auth_token cookie, got %+v", cookies)
+	}
+}
+
+func TestIsCookiesURL(t *testing.T) {
+	tests := map[string]bool{
+		"https://example.com/cookies.json": true,
+		"HTTP://example.com/cookies":       true,
+		"cookies.json":                     false,
+		"-":                                false,
+		"/tmp/http/cookies.txt":            false,
+	}
+	for path, want := range tests {
+		if got := isCookiesURL(path); got != want {
+			t.Errorf("isCookiesURL(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package skool
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+// skoolAuthCookie is the cookie that holds a logged-in Skool session
+const skoolAuthCookie = "auth_token"
+
+// errAuthCookieMissing is returned when the cookies don't include a Skool session
+var errAuthCookieMissing = errors.New("no skool.com auth_token cookie found, export your cookies again while logged in to Skool")
+
+// validateCookies checks that cookies include an unexpired Skool auth token.
+// Session cookies without an expiry are treated as valid.
+func validateCookies(cookies []*network.CookieParam) error {
+	now := time.Now()
+	var expired time.Time
+
+	for _, c := range cookies {
+		if c.Name != skoolAuthCookie || !isSkoolHost(c.Domain) {
+			continue
+		}
+		if c.Expires == nil {
+			return nil
+		}
+		if expires := c.Expires.Time(); expires.After(now) {
+			return nil
+		} else if expires.After(expired) {
+			expired = expires
+		}
+	}
+
+	if !expired.IsZero() {
+		return fmt.Errorf("the skool.com auth_token cookie expired on %s, log in to Skool and export your cookies again", expired.Local().Format("2006-01-02 15:04"))
+	}
+	return errAuthCookieMissing
+}
+
+// latestAuthCookie returns the Skool auth token cookie that stays valid the
+// longest, a session cookie beating any expiry, or nil if there is none
+func latestAuthCookie(cookies []*network.CookieParam) *network.CookieParam {
+	var latest *network.CookieParam
+	for _, c := range cookies {
+		if c.Name != skoolAuthCookie || !isSkoolHost(c.Domain) {
+			continue
+		}
+		switch {
+		case latest == nil, c.Expires == nil:
+			latest = c
+		case latest.Expires != nil && c.Expires.Time().After(latest.Expires.Time()):
+			latest = c
+		}
+	}
+	return latest
+}
+
+// cookieDomains returns the distinct domains of cookies, sorted
+func cookieDomains(cookies []*network.CookieParam) []string {
+	seen := make(map[string]bool)
+	var domains []string
+	for _, c := range cookies {
+		domain := strings.ToLower(c.Domain)
+		if !seen[domain] {
+			seen[domain] = true
+			domains = append(domains, domain)
+		}
+	}
+	sort.Strings(domains)
+	return domains
+}
+
+// printCookiesSummary writes how many cookies there are, their domains and
+// the state of the Skool auth token
+func printCookiesSummary(w io.Writer, cookies []*network.CookieParam, now time.Time) error {
+	authState := "not found"
+	if c := latestAuthCookie(cookies); c != nil {
+		switch {
+		case c.Expires == nil:
+			authState = fmt.Sprintf("found on %s, session cookie without expiry", c.Domain)
+		case c.Expires.Time().After(now):
+			authState = fmt.Sprintf("found on %s, expires %s", c.Domain, c.Expires.Time().Local().Format("2006-01-02 15:04"))
+		default:
+			authState = fmt.Sprintf("found on %s, expired %s", c.Domain, c.Expires.Time().Local().Format("2006-01-02 15:04"))
+		}
+	}
+
+	domains := "-"
+	if d := cookieDomains(cookies); len(d) > 0 {
+		domains = strings.Join(d, ", ")
+	}
+	_, err := fmt.Fprintf(w, "Cookies:    %d\nDomains:    %s\nauth_token: %s\n", len(cookies), domains, authState)
+	return err
+}
+
+// checkCookiesFile reads and parses the -cookies source the way a run would,
+// including cookies piped on stdin, prints a summary and returns an error if
+// it couldn't be parsed or doesn't hold a usable Skool session. Used by
+// -validate-cookies.
+func checkCookiesFile(w io.Writer, stdin io.Reader, config Config) error {
+	if config.CookiesFile == "" {
+		return errors.New("-validate-cookies requires -cookies")
+	}
+	if splitCookiesPaths(config.CookiesFile) != nil {
+		return errors.New("-validate-cookies checks one cookies file at a time")
+	}
+
+	content, name, err := readCookiesSource(config, stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read cookies: %v", err)
+	}
+	cookies, err := parseCookiesContent(name, content, config.CookiesFormat, config.SameSiteZero)
+	if err != nil {
+		return fmt.Errorf("error parsing cookies: %v", err)
+	}
+
+	if err := printCookiesSummary(w, cookies, time.Now()); err != nil {
+		return err
+	}
+	if err := validateCookies(cookies); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, "\nThe cookies hold a Skool session and can be used with -cookies")
+	return err
+}
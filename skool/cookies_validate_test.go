@@ -0,0 +1,165 @@
+package skool
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
+)
+
+func expiresAt(t time.Time) *cdp.TimeSinceEpoch {
+	e := cdp.TimeSinceEpoch(t)
+	return &e
+}
+
+func TestValidateCookies(t *testing.T) {
+	future := expiresAt(time.Now().Add(24 * time.Hour))
+	past := expiresAt(time.Now().Add(-24 * time.Hour))
+
+	tests := []struct {
+		name    string
+		cookies []*network.CookieParam
+		wantErr string
+	}{
+		{
+			name:    "Valid auth token",
+			cookies: []*network.CookieParam{{Name: "auth_token", Domain: ".skool.com", Value: "abc", Expires: future}},
+		},
+		{
+			name:    "Session auth token",
+			cookies: []*network.CookieParam{{Name: "auth_token", Domain: "www.skool.com", Value: "abc"}},
+		},
+		{
+			name:    "Expired auth token",
+			cookies: []*network.CookieParam{{Name: "auth_token", Domain: ".skool.com", Value: "abc", Expires: past}},
+			wantErr: "expired",
+		},
+		{
+			name: "Expired and valid auth tokens",
+			cookies: []*network.CookieParam{
+				{Name: "auth_token", Domain: ".skool.com", Value: "old", Expires: past},
+				{Name: "auth_token", Domain: "www.skool.com", Value: "new", Expires: future},
+			},
+		},
+		{
+			name:    "Missing auth token",
+			cookies: []*network.CookieParam{{Name: "client_id", Domain: ".skool.com", Value: "abc", Expires: future}},
+			wantErr: "no skool.com auth_token",
+		},
+		{
+			name:    "Auth token for another site",
+			cookies: []*network.CookieParam{{Name: "auth_token", Domain: "example.com", Value: "abc", Expires: future}},
+			wantErr: "no skool.com auth_token",
+		},
+		{
+			name:    "No cookies",
+			wantErr: "no skool.com auth_token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCookies(tt.cookies)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateCookies() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateCookies() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateCookies_MissingIsSentinel(t *testing.T) {
+	if err := validateCookies(nil); !errors.Is(err, errAuthCookieMissing) {
+		t.Errorf("validateCookies(nil) = %v, want errAuthCookieMissing", err)
+	}
+}
+
+func TestCheckCookiesFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		wantOutput []string
+		wantErr    string
+	}{
+		{
+			name:       "Valid",
+			file:       "valid.txt",
+			wantOutput: []string{"Cookies:    3", "Domains:    .loom.com, .skool.com", "auth_token: found on .skool.com, expires 2100-", "can be used with -cookies"},
+		},
+		{
+			name:       "Expired",
+			file:       "expired.txt",
+			wantOutput: []string{"Cookies:    2", "auth_token: found on .skool.com, expired 2020-"},
+			wantErr:    "expired",
+		},
+		{
+			name:       "No auth token",
+			file:       "no_auth.json",
+			wantOutput: []string{"Cookies:    1", "auth_token: not found"},
+			wantErr:    "no skool.com auth_token",
+		},
+		{
+			name:    "Invalid JSON",
+			file:    "invalid.json",
+			wantErr: "error parsing cookies",
+		},
+		{
+			name:    "Missing file",
+			file:    "missing.txt",
+			wantErr: "failed to read cookies",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			config := Config{CookiesFile: filepath.Join("testdata", "cookies", tt.file)}
+			err := checkCookiesFile(&out, nil, config)
+
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("checkCookiesFile() unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("checkCookiesFile() error = %v, want error containing %q", err, tt.wantErr)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("Expected output to contain %q, got:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestCheckCookiesFile_RequiresCookies(t *testing.T) {
+	if err := checkCookiesFile(io.Discard, nil, Config{}); err == nil || !strings.Contains(err.Error(), "requires -cookies") {
+		t.Errorf("checkCookiesFile() error = %v, want a missing -cookies error", err)
+	}
+}
+
+func TestCheckCookiesFile_Stdin(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "cookies", "valid.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	var out strings.Builder
+	if err := checkCookiesFile(&out, bytes.NewReader(content), Config{CookiesFile: stdinCookiesPath}); err != nil {
+		t.Fatalf("checkCookiesFile() with -cookies=- error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Cookies:    3") {
+		t.Errorf("Expected the piped cookies to be counted, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,108 @@
+package skool
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// courseMetadata names the community and course a classroom belongs to
+type courseMetadata struct {
+	Community string // community slug, e.g. "my-school"
+	Course    string // course title as shown in the classroom
+}
+
+// extractCourseMetadata reads the community slug and course title from
+// __NEXT_DATA__, falling back to the classroom URL's path when they're missing
+func extractCourseMetadata(data map[string]interface{}, classroomURL string) courseMetadata {
+	var meta courseMetadata
+
+	if props, ok := data["props"].(map[string]interface{}); ok {
+		if pageProps, ok := props["pageProps"].(map[string]interface{}); ok {
+			if group, ok := pageProps["currentGroup"].(map[string]interface{}); ok {
+				meta.Community, _ = group["name"].(string)
+			}
+		}
+	}
+
+	if course, ok := courseTree(data); ok {
+		if courseObj, ok := course["course"].(map[string]interface{}); ok {
+			if title := lessonTitle(courseObj); title != untitledLesson {
+				meta.Course = title
+			}
+		}
+	}
+
+	if parsed, err := parseSkoolURL(classroomURL); err == nil {
+		if meta.Community == "" {
+			meta.Community = parsed.Community
+		}
+		if meta.Course == "" {
+			meta.Course = parsed.Course
+		}
+	}
+	if meta.Course == "" {
+		meta.Course = "classroom"
+	}
+	return meta
+}
+
+// defaultOutputTemplate keeps each course in <output>/<community>/<course>/
+const defaultOutputTemplate = placeholderCommunity + "/" + placeholderCourse
+
+// Placeholders filled in from the course metadata in -output-template
+const (
+	placeholderCommunity = "{community}"
+	placeholderCourse    = "{course}"
+)
+
+// outputPlaceholderRegex finds the placeholders used in -output-template
+var outputPlaceholderRegex = regexp.MustCompile(`\{[^{}/\\]*\}`)
+
+// validateOutputTemplate checks that template only uses known placeholders
+func validateOutputTemplate(template string) error {
+	for _, placeholder := range outputPlaceholderRegex.FindAllString(template, -1) {
+		if placeholder != placeholderCommunity && placeholder != placeholderCourse {
+			return fmt.Errorf("unknown placeholder %s in -output-template, use %s and %s", placeholder, placeholderCommunity, placeholderCourse)
+		}
+	}
+	return nil
+}
+
+// OutputDir returns the directory for the course's videos: template expanded
+// under base. Each segment of the expanded template is sanitized on its own,
+// so a course title can't add directories or climb out of base. An empty
+// template puts the videos straight into base.
+func (m courseMetadata) OutputDir(base, template string) string {
+	replacer := strings.NewReplacer(placeholderCommunity, m.Community, placeholderCourse, m.Course)
+	dir := base
+	for _, segment := range strings.FieldsFunc(template, isPathSeparator) {
+		dir = filepath.Join(dir, sanitizePathSegment(replacer.Replace(segment)))
+	}
+	return dir
+}
+
+// isPathSeparator reports whether r separates directories in -output-template,
+// accepting / and \ on every platform
+func isPathSeparator(r rune) bool {
+	return r == '/' || r == '\\'
+}
+
+// sanitizePathSegment makes s safe to use as a single directory name on all platforms
+func sanitizePathSegment(s string) string {
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r < 0x20, strings.ContainsRune(`/\:*?"<>|`, r):
+			return '_'
+		}
+		return r
+	}, s)
+
+	// Windows doesn't allow trailing dots or spaces
+	s = strings.TrimRight(strings.TrimSpace(s), ". ")
+	if s == "" {
+		return "_"
+	}
+	return s
+}
@@ -0,0 +1,129 @@
+package skool
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestExtractCourseMetadata(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         map[string]interface{}
+		classroomURL string
+		expected     courseMetadata
+	}{
+		{
+			name: "From __NEXT_DATA__",
+			data: map[string]interface{}{
+				"props": map[string]interface{}{
+					"pageProps": map[string]interface{}{
+						"currentGroup": map[string]interface{}{"name": "my-school"},
+						"course": map[string]interface{}{
+							"course": map[string]interface{}{
+								"id":       "abc123",
+								"metadata": map[string]interface{}{"title": "Marketing 101"},
+							},
+						},
+					},
+				},
+			},
+			classroomURL: "https://www.skool.com/other/classroom/abc123",
+			expected:     courseMetadata{Community: "my-school", Course: "Marketing 101"},
+		},
+		{
+			name: "Course title without group",
+			data: map[string]interface{}{
+				"props": map[string]interface{}{
+					"pageProps": map[string]interface{}{
+						"course": map[string]interface{}{
+							"course": map[string]interface{}{"name": "marketing-101"},
+						},
+					},
+				},
+			},
+			classroomURL: "https://www.skool.com/my-school/classroom/abc123",
+			expected:     courseMetadata{Community: "my-school", Course: "marketing-101"},
+		},
+		{
+			name:         "Fallback to URL path",
+			data:         map[string]interface{}{},
+			classroomURL: "https://www.skool.com/my-school/classroom/abc123?md=lesson42",
+			expected:     courseMetadata{Community: "my-school", Course: "abc123"},
+		},
+		{
+			name:         "Classroom index",
+			data:         nil,
+			classroomURL: "https://www.skool.com/my-school/classroom",
+			expected:     courseMetadata{Community: "my-school", Course: "classroom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := extractCourseMetadata(tt.data, tt.classroomURL)
+			if got != tt.expected {
+				t.Errorf("extractCourseMetadata() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCourseMetadataOutputDir(t *testing.T) {
+	meta := courseMetadata{Community: "my-school", Course: "Sales: Part 1/2?"}
+	tests := []struct {
+		name     string
+		meta     courseMetadata
+		template string
+		want     string
+	}{
+		{"Default", meta, defaultOutputTemplate, filepath.Join("downloads", "my-school", "Sales_ Part 1_2_")},
+		{"Course only", meta, "{course}", filepath.Join("downloads", "Sales_ Part 1_2_")},
+		{"Literal text", meta, "skool/{community} - {course}", filepath.Join("downloads", "skool", "my-school - Sales_ Part 1_2_")},
+		{"Backslash separator", meta, `{community}\{course}`, filepath.Join("downloads", "my-school", "Sales_ Part 1_2_")},
+		{"Empty template", meta, "", "downloads"},
+		{"Missing community", courseMetadata{Course: "classroom"}, defaultOutputTemplate, filepath.Join("downloads", "_", "classroom")},
+		{"No climbing out", courseMetadata{Community: "..", Course: "x"}, "{community}/../{course}", filepath.Join("downloads", "_", "_", "x")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.meta.OutputDir("downloads", tt.template); got != tt.want {
+				t.Errorf("OutputDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateOutputTemplate(t *testing.T) {
+	for _, template := range []string{"", defaultOutputTemplate, "{course}", "archive/{community}"} {
+		if err := validateOutputTemplate(template); err != nil {
+			t.Errorf("validateOutputTemplate(%q) error = %v", template, err)
+		}
+	}
+	if err := validateOutputTemplate("{community}/{lesson}"); err == nil {
+		t.Error("Expected an error for the unknown {lesson} placeholder")
+	}
+}
+
+func TestSanitizePathSegment(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "Marketing 101", expected: "Marketing 101"},
+		{input: `a\b/c:d*e?f"g<h>i|j`, expected: "a_b_c_d_e_f_g_h_i_j"},
+		{input: "  Trailing dots... ", expected: "Trailing dots"},
+		{input: "Tab\tand\nnewline", expected: "Tab_and_newline"},
+		{input: "..", expected: "_"},
+		{input: "", expected: "_"},
+		{input: "Émojis 🎉 ok", expected: "Émojis 🎉 ok"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := sanitizePathSegment(tt.input); got != tt.expected {
+				t.Errorf("sanitizePathSegment(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package skool
+
+import (
+	"context"
+	"os"
+
+	"github.com/chromedp/chromedp"
+)
+
+// saveDebugCapture writes a full-page PNG screenshot to -debug-screenshot and
+// the page HTML to -debug-html, showing what the browser saw when a scrape
+// failed or found nothing. Failures are only warnings.
+func saveDebugCapture(ctx context.Context, config Config) {
+	if config.DebugScreenshot == "" && config.DebugHTML == "" {
+		return
+	}
+
+	if config.DebugScreenshot != "" {
+		// Quality 100 makes chromedp capture a PNG instead of a JPEG
+		var png []byte
+		if err := chromedp.Run(ctx, chromedp.FullScreenshot(&png, 100)); err != nil {
+			console.Warningf("Failed to capture debug screenshot: %v", err)
+		} else if err := os.WriteFile(config.DebugScreenshot, png, 0644); err != nil {
+			console.Warningf("Failed to write debug screenshot: %v", err)
+		} else {
+			console.Info("Debug screenshot written to:", config.DebugScreenshot)
+		}
+	}
+
+	if config.DebugHTML != "" {
+		var html string
+		if err := chromedp.Run(ctx, chromedp.OuterHTML("html", &html)); err != nil {
+			console.Warningf("Failed to capture page HTML: %v", err)
+		} else if err := os.WriteFile(config.DebugHTML, []byte(html), 0644); err != nil {
+			console.Warningf("Failed to write page HTML: %v", err)
+		} else {
+			console.Info("Page HTML written to:", config.DebugHTML)
+		}
+	}
+}
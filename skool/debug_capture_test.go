@@ -0,0 +1,20 @@
+package skool
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestSaveDebugCapture_NoopWithoutFlags(t *testing.T) {
+	var out bytes.Buffer
+	original := console
+	console = newLogger(levelVerbose, &out)
+	defer func() { console = original }()
+
+	// A context without a browser would make any capture attempt fail and warn
+	saveDebugCapture(context.Background(), Config{})
+	if out.Len() != 0 {
+		t.Errorf("Expected no output without -debug-screenshot or -debug-html, got:\n%s", out.String())
+	}
+}
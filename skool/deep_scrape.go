@@ -0,0 +1,221 @@
+package skool
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// lessonQueryParam is the query parameter Skool uses to select a lesson or set
+const lessonQueryParam = "md"
+
+// courseUnitSet is the unitType of a set, which Skool shows as a module
+const courseUnitSet = "set"
+
+// maxTabs caps -tabs so a large course doesn't overwhelm the browser or Skool
+const maxTabs = 8
+
+// courseNodeIDs walks the course tree and returns the IDs of lessons and sets
+// whose video wasn't resolved in the tree, along with the number of sets that
+// appear collapsed (no children loaded)
+func courseNodeIDs(data map[string]interface{}) ([]string, int) {
+	course, ok := courseTree(data)
+	if !ok {
+		return nil, 0
+	}
+
+	var ids []string
+	collapsed := 0
+	seen := make(map[string]bool)
+
+	var walk func(node map[string]interface{}, isRoot bool)
+	walk = func(node map[string]interface{}, isRoot bool) {
+		children, _ := node["children"].([]interface{})
+
+		if courseObj, ok := node["course"].(map[string]interface{}); ok && !isRoot {
+			if unitType, _ := courseObj["unitType"].(string); unitType == courseUnitSet && len(children) == 0 {
+				collapsed++
+			}
+
+			videoLink := ""
+			if metadata, ok := courseObj["metadata"].(map[string]interface{}); ok {
+				videoLink, _ = metadata["videoLink"].(string)
+			}
+
+			if id, ok := courseObj["id"].(string); ok && id != "" && videoLink == "" && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+
+		for _, child := range children {
+			if childMap, ok := child.(map[string]interface{}); ok {
+				walk(childMap, false)
+			}
+		}
+	}
+	walk(course, true)
+
+	return ids, collapsed
+}
+
+// findCourseNode returns the node of the lesson or set with the given ID,
+// along with the title of the set it's in and its position in the tree
+func findCourseNode(node map[string]interface{}, id string) (map[string]interface{}, string, []int, bool) {
+	return findCourseNodeIn(node, id, "", nil)
+}
+
+func findCourseNodeIn(node map[string]interface{}, id, module string, position []int) (map[string]interface{}, string, []int, bool) {
+	if courseObj, ok := node["course"].(map[string]interface{}); ok {
+		if nodeID, _ := courseObj["id"].(string); nodeID == id {
+			return node, module, position, true
+		}
+		if unitType, _ := courseObj["unitType"].(string); unitType == courseUnitSet {
+			module = lessonTitle(courseObj)
+		}
+	}
+
+	children, _ := node["children"].([]interface{})
+	for i, child := range children {
+		if childMap, ok := child.(map[string]interface{}); ok {
+			if found, foundModule, foundPosition, ok := findCourseNodeIn(childMap, id, module, childPosition(position, i)); ok {
+				return found, foundModule, foundPosition, true
+			}
+		}
+	}
+	return nil, "", nil, false
+}
+
+// lessonVideos returns the videos of a single lesson, or of every lesson in a
+// set, reporting false if the ID isn't in the course tree
+func lessonVideos(data map[string]interface{}, id string) ([]VideoEntry, skippedLessons, bool) {
+	course, ok := courseTree(data)
+	if !ok {
+		return nil, skippedLessons{}, false
+	}
+	node, module, position, ok := findCourseNode(course, id)
+	if !ok {
+		return nil, skippedLessons{}, false
+	}
+	videos, skipped := walkCourseVideos(node, module, position)
+	return videos, skipped, true
+}
+
+// lessonURL builds the URL of a single lesson or set within a classroom
+func lessonURL(classroomURL, id string) string {
+	u, err := url.Parse(classroomURL)
+	if err != nil {
+		return classroomURL
+	}
+	u.RawQuery = url.Values{lessonQueryParam: []string{id}}.Encode()
+	u.Fragment = ""
+	return u.String()
+}
+
+// deepScrape visits every lesson and set that the initial __NEXT_DATA__ didn't
+// resolve, re-extracting video links from each page's course tree
+func deepScrape(ctx context.Context, config Config, data map[string]interface{}, videos []VideoEntry) []VideoEntry {
+	ids, _ := courseNodeIDs(data)
+	if len(ids) == 0 {
+		return videos
+	}
+
+	console.Infof("Deep scrape: visiting %d lesson(s) and set(s) not resolved on the classroom page", len(ids))
+	defer timings.Start("deep scrape")()
+
+	seen := make(map[string]bool)
+	for _, v := range videos {
+		seen[v.Key()] = true
+	}
+	var skipped skippedLessons
+
+	// Drop any lesson selection or tracking parameters from the starting URL
+	classroomURL := config.SkoolURL
+	if parsed, err := parseSkoolURL(config.SkoolURL); err == nil && parsed.IsClassroom {
+		classroomURL = parsed.ClassroomURL()
+	}
+
+	// Each lesson is visited independently, results are merged in course order
+	type lessonPage struct {
+		videos  []VideoEntry
+		skipped skippedLessons
+	}
+	pages := make([]lessonPage, len(ids))
+	runInTabs(ctx, len(ids), config.Tabs, newBrowserTab, func(tabCtx context.Context, i int) {
+		target := lessonURL(classroomURL, ids[i])
+		console.Debugf("[%d/%d] Visiting %s", i+1, len(ids), target)
+
+		var html string
+		if err := chromedp.Run(tabCtx, chromedp.Tasks{
+			chromedp.Navigate(target),
+			waitReady(pageReadyJS, time.Duration(config.WaitTime)*time.Second),
+			chromedp.OuterHTML("html", &html),
+		}); err != nil {
+			console.Warningf("Failed to load lesson %s: %v", target, err)
+			return
+		}
+
+		lessonData, err := extractNextDataJSON(html)
+		if err != nil {
+			console.Warningf("No course data on lesson %s: %v", target, err)
+			return
+		}
+
+		pages[i].videos, pages[i].skipped = walkNextDataVideos(lessonData)
+	})
+
+	for _, page := range pages {
+		for _, v := range page.videos {
+			if key := v.Key(); !seen[key] {
+				seen[key] = true
+				videos = append(videos, v)
+			}
+		}
+		skipped.add(page.skipped)
+	}
+
+	reportSkippedLessons(skipped)
+	return videos
+}
+
+// newBrowserTab opens a new tab in the browser that ctx belongs to
+func newBrowserTab(ctx context.Context) (context.Context, context.CancelFunc) {
+	return chromedp.NewContext(ctx)
+}
+
+// runInTabs calls visit for every index in [0, n), spread over at most tabs
+// tabs created with newTab. With a single tab, everything runs in ctx's own
+// tab one after another, as before tabs were configurable.
+func runInTabs(ctx context.Context, n, tabs int, newTab func(context.Context) (context.Context, context.CancelFunc), visit func(tabCtx context.Context, i int)) {
+	if tabs <= 1 || n <= 1 {
+		for i := 0; i < n; i++ {
+			visit(ctx, i)
+		}
+		return
+	}
+	if tabs > n {
+		tabs = n
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for t := 0; t < tabs; t++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tabCtx, cancel := newTab(ctx)
+			defer cancel()
+			for i := range jobs {
+				visit(tabCtx, i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
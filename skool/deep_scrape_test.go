@@ -0,0 +1,186 @@
+package skool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestCourseNodeIDs(t *testing.T) {
+	node := func(id, unitType, videoLink string, children ...interface{}) map[string]interface{} {
+		n := map[string]interface{}{
+			"course": map[string]interface{}{
+				"id":       id,
+				"unitType": unitType,
+				"metadata": map[string]interface{}{"videoLink": videoLink},
+			},
+		}
+		if len(children) > 0 {
+			n["children"] = children
+		}
+		return n
+	}
+
+	root := node("root", "course", "",
+		node("set1", "set", "",
+			node("lesson1", "module", "https://www.loom.com/share/abc123"),
+			node("lesson2", "module", ""),
+		),
+		node("set2", "set", ""),
+	)
+	data := map[string]interface{}{
+		"props": map[string]interface{}{
+			"pageProps": map[string]interface{}{"course": root},
+		},
+	}
+
+	ids, collapsed := courseNodeIDs(data)
+	expected := []string{"set1", "lesson2", "set2"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("courseNodeIDs() ids = %v, want %v", ids, expected)
+	}
+	if collapsed != 1 {
+		t.Errorf("courseNodeIDs() collapsed = %d, want 1", collapsed)
+	}
+}
+
+func TestCourseNodeIDs_NoCourse(t *testing.T) {
+	ids, collapsed := courseNodeIDs(map[string]interface{}{})
+	if len(ids) != 0 || collapsed != 0 {
+		t.Errorf("Expected no IDs for missing course, got %v (%d collapsed)", ids, collapsed)
+	}
+}
+
+func TestLessonURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		classroom string
+		id        string
+		expected  string
+	}{
+		{
+			name:      "Plain classroom URL",
+			classroom: "https://www.skool.com/school/classroom/abc",
+			id:        "lesson1",
+			expected:  "https://www.skool.com/school/classroom/abc?md=lesson1",
+		},
+		{
+			name:      "Replaces existing lesson and fragment",
+			classroom: "https://www.skool.com/school/classroom/abc?md=old#top",
+			id:        "lesson2",
+			expected:  "https://www.skool.com/school/classroom/abc?md=lesson2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lessonURL(tt.classroom, tt.id); got != tt.expected {
+				t.Errorf("lessonURL() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLessonVideos(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join(fixturesDir, "basic_course.html"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	data, err := extractNextDataJSON(string(content))
+	if err != nil {
+		t.Fatalf("extractNextDataJSON() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		id        string
+		wantFound bool
+		expected  []string
+		module    string
+	}{
+		{
+			name:      "Single lesson",
+			id:        "m2",
+			wantFound: true,
+			expected:  []string{"https://www.loom.com/share/fed654cba321"},
+			module:    "Getting Started",
+		},
+		{
+			name:      "Whole set",
+			id:        "s2",
+			wantFound: true,
+			expected:  []string{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", "https://www.loom.com/share/abc123def456"},
+			module:    "Deep Dive",
+		},
+		{
+			name:      "Unknown lesson",
+			id:        "nope",
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			videos, _, found := lessonVideos(data, tt.id)
+			if found != tt.wantFound {
+				t.Fatalf("lessonVideos() found = %v, want %v", found, tt.wantFound)
+			}
+			if urls := videoURLs(videos); !reflect.DeepEqual(urls, tt.expected) {
+				t.Errorf("lessonVideos() = %v, want %v", urls, tt.expected)
+			}
+			for _, v := range videos {
+				if v.Module != tt.module {
+					t.Errorf("Expected module %q for %s, got %q", tt.module, v.URL, v.Module)
+				}
+			}
+		})
+	}
+}
+
+func TestRunInTabs(t *testing.T) {
+	type tabKey struct{}
+
+	for _, tabs := range []int{1, 3, 10} {
+		var mu sync.Mutex
+		opened := 0
+		visited := make(map[int]int)
+		tabsUsed := make(map[interface{}]bool)
+
+		newTab := func(ctx context.Context) (context.Context, context.CancelFunc) {
+			mu.Lock()
+			opened++
+			id := opened
+			mu.Unlock()
+			return context.WithValue(ctx, tabKey{}, id), func() {}
+		}
+
+		runInTabs(context.Background(), 5, tabs, newTab, func(tabCtx context.Context, i int) {
+			mu.Lock()
+			defer mu.Unlock()
+			visited[i]++
+			tabsUsed[tabCtx.Value(tabKey{})] = true
+		})
+
+		for i := 0; i < 5; i++ {
+			if visited[i] != 1 {
+				t.Errorf("tabs=%d: index %d visited %d times, want 1", tabs, i, visited[i])
+			}
+		}
+
+		wantOpened := tabs
+		if tabs == 1 {
+			wantOpened = 0 // the existing tab is reused
+		} else if tabs > 5 {
+			wantOpened = 5
+		}
+		if opened != wantOpened {
+			t.Errorf("tabs=%d: opened %d tabs, want %d", tabs, opened, wantOpened)
+		}
+		if len(tabsUsed) > max(tabs, 1) {
+			t.Errorf("tabs=%d: visits spread over %d tabs", tabs, len(tabsUsed))
+		}
+	}
+}
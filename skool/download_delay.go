@@ -0,0 +1,31 @@
+package skool
+
+import (
+	"context"
+	"time"
+)
+
+// sleepFunc waits for d, or until ctx is done
+type sleepFunc func(ctx context.Context, d time.Duration)
+
+// sleepContext is the sleepFunc used outside of tests
+func sleepContext(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// pauseBeforeDownload waits -download-delay before every download but the
+// first. With -human the delay varies like the page waits. A run that ends
+// during the pause is picked up by the deadline check that follows.
+func pauseBeforeDownload(ctx context.Context, index int, delay time.Duration, sleep sleepFunc) {
+	if index == 0 || delay <= 0 {
+		return
+	}
+	d := human.Jitter(delay)
+	console.Debugf("Waiting %s before the next download", d)
+	sleep(ctx, d)
+}
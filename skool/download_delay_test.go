@@ -0,0 +1,53 @@
+package skool
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPauseBeforeDownload(t *testing.T) {
+	previous := console
+	console = newLogger(levelQuiet, io.Discard)
+	defer func() {
+		console = previous
+	}()
+
+	tests := []struct {
+		name  string
+		delay time.Duration
+		want  []time.Duration
+	}{
+		{"No delay", 0, nil},
+		{"Between each download", 5 * time.Second, []time.Duration{5 * time.Second, 5 * time.Second, 5 * time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Record the waits of a four-video loop instead of sleeping
+			var slept []time.Duration
+			sleep := func(ctx context.Context, d time.Duration) {
+				slept = append(slept, d)
+			}
+			for i := 0; i < 4; i++ {
+				pauseBeforeDownload(context.Background(), i, tt.delay, sleep)
+			}
+			if !reflect.DeepEqual(slept, tt.want) {
+				t.Errorf("Slept %v, want %v", slept, tt.want)
+			}
+		})
+	}
+}
+
+func TestSleepContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	sleepContext(ctx, time.Minute)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected a cancelled context to end the wait, took %v", elapsed)
+	}
+}
@@ -0,0 +1,69 @@
+package skool
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Event types emitted with -json
+const (
+	eventVideo          = "video"
+	eventOutput         = "output"
+	eventDownloadStart  = "download_start"
+	eventDownloadResult = "download_result"
+	eventSummary        = "summary"
+)
+
+// Event is a single line of the -json output
+type Event struct {
+	Type      string    `json:"type"`
+	Time      time.Time `json:"time"`
+	Index     int       `json:"index,omitempty"` // 1-based position of the video
+	Total     int       `json:"total,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Success   *bool     `json:"success,omitempty"`
+	File      string    `json:"file,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	Succeeded int       `json:"succeeded,omitempty"`
+	Failed    int       `json:"failed,omitempty"`
+}
+
+// eventEmitter writes events as newline-delimited JSON. A disabled emitter
+// writes nothing, so call sites don't need to check the flag.
+type eventEmitter struct {
+	enabled bool
+	enc     *json.Encoder
+	now     func() time.Time
+}
+
+// events is the event emitter used throughout the application
+var events = newEventEmitter(nil)
+
+// newEventEmitter returns an emitter writing to out, or a disabled one if out is nil
+func newEventEmitter(out io.Writer) *eventEmitter {
+	if out == nil {
+		return &eventEmitter{now: time.Now}
+	}
+	return &eventEmitter{enabled: true, enc: json.NewEncoder(out), now: time.Now}
+}
+
+// Emit writes e, stamping it with the current time
+func (e *eventEmitter) Emit(event Event) {
+	if !e.enabled {
+		return
+	}
+	event.Time = e.now().UTC()
+	_ = e.enc.Encode(event)
+}
+
+// DownloadResult emits the outcome of a single download
+func (e *eventEmitter) DownloadResult(index, total int, url, file string, err error) {
+	success := err == nil
+	event := Event{Type: eventDownloadResult, Index: index, Total: total, URL: url, Success: &success, File: file}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	e.Emit(event)
+}
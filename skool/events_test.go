@@ -0,0 +1,62 @@
+package skool
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEventEmitter(t *testing.T) {
+	var buf bytes.Buffer
+	emitter := newEventEmitter(&buf)
+	emitter.now = func() time.Time {
+		return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	}
+
+	emitter.Emit(Event{Type: eventVideo, Index: 1, Total: 2, URL: "https://www.loom.com/share/abc123"})
+	emitter.DownloadResult(1, 2, "https://www.loom.com/share/abc123", "downloads/Intro.mp4", nil)
+	emitter.DownloadResult(2, 2, "https://www.loom.com/share/def456", "", errors.New("exit status 1"))
+	emitter.Emit(Event{Type: eventSummary, Total: 2, Succeeded: 1, Failed: 1})
+
+	var got []map[string]interface{}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var event map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("Line is not valid JSON: %q: %v", scanner.Text(), err)
+		}
+		got = append(got, event)
+	}
+
+	if len(got) != 4 {
+		t.Fatalf("Expected 4 events, got %d", len(got))
+	}
+
+	if got[0]["type"] != eventVideo || got[0]["time"] != "2024-01-02T03:04:05Z" {
+		t.Errorf("Unexpected video event: %v", got[0])
+	}
+	if _, ok := got[0]["success"]; ok {
+		t.Errorf("Video event should not include success: %v", got[0])
+	}
+
+	if got[1]["success"] != true || got[1]["file"] != "downloads/Intro.mp4" {
+		t.Errorf("Unexpected successful result: %v", got[1])
+	}
+	if got[2]["success"] != false || got[2]["error"] != "exit status 1" {
+		t.Errorf("Unexpected failed result: %v", got[2])
+	}
+	if got[3]["succeeded"] != float64(1) || got[3]["failed"] != float64(1) {
+		t.Errorf("Unexpected summary: %v", got[3])
+	}
+}
+
+func TestEventEmitter_Disabled(t *testing.T) {
+	emitter := newEventEmitter(nil)
+	emitter.Emit(Event{Type: eventSummary})
+	if emitter.enabled {
+		t.Error("Emitter without output should be disabled")
+	}
+}
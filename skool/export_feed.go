@@ -0,0 +1,119 @@
+package skool
+
+import (
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"time"
+)
+
+// itunesNamespace is declared on the feed so itunes:episode can carry each
+// lesson's position, which podcast apps sort by
+const itunesNamespace = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+
+// rssFeed is a minimal RSS 2.0 document for -export-feed
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Itunes  string     `xml:"xmlns:itunes,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title     string        `xml:"title"`
+	Link      string        `xml:"link"`
+	GUID      rssGUID       `xml:"guid"`
+	Category  string        `xml:"category,omitempty"`
+	PubDate   string        `xml:"pubDate,omitempty"`
+	Episode   int           `xml:"itunes:episode"`
+	Enclosure *rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// rssEnclosure points at a media file; RSS requires a length, 0 when unknown
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// buildFeed returns a feed with one item per video, in course order. Only
+// videos with a media file (a direct link or a captured stream) get an
+// enclosure, since the others are pages that need yt-dlp.
+func buildFeed(course courseMetadata, classroomURL string, videos []VideoEntry) rssFeed {
+	title := course.Course
+	if title == "" {
+		title = course.Community
+	}
+
+	feed := rssFeed{
+		Version: "2.0",
+		Itunes:  itunesNamespace,
+		Channel: rssChannel{
+			Title:       title,
+			Link:        classroomURL,
+			Description: fmt.Sprintf("Videos of %s on Skool", title),
+		},
+	}
+	for i, video := range videos {
+		item := rssItem{
+			Title:    video.Title,
+			Link:     video.URL,
+			GUID:     rssGUID{IsPermaLink: true, Value: video.URL},
+			Category: video.Module,
+			Episode:  i + 1,
+		}
+		if item.Title == "" {
+			item.Title = untitledLesson
+		}
+		if !video.Added.IsZero() {
+			item.PubDate = video.Added.UTC().Format(time.RFC1123Z)
+		}
+		item.Enclosure = feedEnclosure(video)
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+	return feed
+}
+
+// feedEnclosure returns the media file of a video, or nil if it only has a page
+func feedEnclosure(video VideoEntry) *rssEnclosure {
+	media := video.MediaURL
+	if media == "" && video.Platform == platformDirect {
+		media = video.URL
+	}
+	if media == "" {
+		return nil
+	}
+
+	mediaType := "application/octet-stream"
+	if u, err := url.Parse(media); err == nil {
+		if t := mime.TypeByExtension(path.Ext(u.Path)); t != "" {
+			mediaType = t
+		}
+	}
+	return &rssEnclosure{URL: media, Type: mediaType}
+}
+
+// writeFeed writes the -export-feed file
+func writeFeed(filePath string, feed rssFeed) error {
+	content, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return err
+	}
+	content = append([]byte(xml.Header), content...)
+	return os.WriteFile(filePath, append(content, '\n'), 0644)
+}
@@ -0,0 +1,82 @@
+package skool
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteFeed(t *testing.T) {
+	added := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	videos := []VideoEntry{
+		{URL: "https://www.loom.com/share/abc123", Title: "Welcome", Module: "Basics", Platform: platformLoom, Added: added},
+		{URL: "https://cdn.example.com/lesson2.mp4", Platform: platformDirect},
+	}
+	feed := buildFeed(courseMetadata{Community: "my-school", Course: "Starter Course"}, "https://www.skool.com/my-school/classroom/abc", videos)
+
+	path := filepath.Join(t.TempDir(), "feed.xml")
+	if err := writeFeed(path, feed); err != nil {
+		t.Fatalf("writeFeed() error: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read feed: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "<?xml") || !strings.Contains(string(content), `xmlns:itunes="`+itunesNamespace+`"`) {
+		t.Errorf("Expected an XML header and the itunes namespace, got:\n%s", content)
+	}
+
+	var parsed struct {
+		XMLName xml.Name `xml:"rss"`
+		Version string   `xml:"version,attr"`
+		Channel struct {
+			Title string `xml:"title"`
+			Link  string `xml:"link"`
+			Items []struct {
+				Title     string `xml:"title"`
+				Link      string `xml:"link"`
+				GUID      string `xml:"guid"`
+				Category  string `xml:"category"`
+				PubDate   string `xml:"pubDate"`
+				Episode   int    `xml:"episode"`
+				Enclosure *struct {
+					URL  string `xml:"url,attr"`
+					Type string `xml:"type,attr"`
+				} `xml:"enclosure"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}
+	if err := xml.Unmarshal(content, &parsed); err != nil {
+		t.Fatalf("Feed isn't valid XML: %v", err)
+	}
+
+	if parsed.Version != "2.0" || parsed.Channel.Title != "Starter Course" || parsed.Channel.Link != "https://www.skool.com/my-school/classroom/abc" {
+		t.Errorf("Unexpected feed header: version %q, title %q, link %q", parsed.Version, parsed.Channel.Title, parsed.Channel.Link)
+	}
+	items := parsed.Channel.Items
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(items))
+	}
+
+	first := items[0]
+	if first.Title != "Welcome" || first.Link != videos[0].URL || first.GUID != videos[0].URL || first.Category != "Basics" || first.Episode != 1 {
+		t.Errorf("Unexpected first item: %+v", first)
+	}
+	if first.PubDate != "Tue, 02 Jan 2024 03:04:05 +0000" {
+		t.Errorf("pubDate = %q", first.PubDate)
+	}
+	if first.Enclosure != nil {
+		t.Errorf("Expected no enclosure for a Loom page, got %+v", first.Enclosure)
+	}
+
+	second := items[1]
+	if second.Title != untitledLesson || second.Episode != 2 || second.PubDate != "" {
+		t.Errorf("Unexpected second item: %+v", second)
+	}
+	if second.Enclosure == nil || second.Enclosure.URL != videos[1].URL || second.Enclosure.Type != "video/mp4" {
+		t.Errorf("Expected an mp4 enclosure for a direct link, got %+v", second.Enclosure)
+	}
+}
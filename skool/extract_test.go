@@ -0,0 +1,54 @@
+package skool_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"skool-downloader/skool"
+)
+
+func TestExtractVideos_FromHTML(t *testing.T) {
+	config := skool.DefaultConfig()
+	config.FromHTML = filepath.Join("testdata", "nextdata", "basic_course.html")
+
+	videos, err := skool.ExtractVideos(context.Background(), config)
+	if err != nil {
+		t.Fatalf("ExtractVideos() error: %v", err)
+	}
+
+	var got []string
+	for _, video := range videos {
+		got = append(got, video.Title+" "+video.URL)
+	}
+	expected := []string{
+		"Welcome https://www.loom.com/share/abc123def456",
+		"Setup https://www.loom.com/share/fed654cba321",
+		"Overview https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("ExtractVideos() = %q, want %q", got, expected)
+	}
+}
+
+func TestExtractVideos_Platforms(t *testing.T) {
+	config := skool.DefaultConfig()
+	config.FromHTML = filepath.Join("testdata", "nextdata", "basic_course.html")
+	config.Platforms = "youtube"
+
+	videos, err := skool.ExtractVideos(context.Background(), config)
+	if err != nil {
+		t.Fatalf("ExtractVideos() error: %v", err)
+	}
+	if len(videos) != 1 || videos[0].ID != "dQw4w9WgXcQ" {
+		t.Errorf("ExtractVideos() with Platforms = %+v, want only the YouTube video", videos)
+	}
+}
+
+func TestExtractVideos_MissingURL(t *testing.T) {
+	if _, err := skool.ExtractVideos(context.Background(), skool.DefaultConfig()); !errors.Is(err, skool.ErrMissingURL) {
+		t.Errorf("ExtractVideos() without a URL error = %v, want %v", err, skool.ErrMissingURL)
+	}
+}
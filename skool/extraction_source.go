@@ -0,0 +1,74 @@
+package skool
+
+import (
+	"errors"
+	"fmt"
+)
+
+// extractionSource is where extractVideos found a page's videos
+type extractionSource int
+
+const (
+	// sourceNone means neither __NEXT_DATA__ nor the regex fallback found a
+	// video, and the page had no course tree either
+	sourceNone extractionSource = iota
+	// sourceNextData is the fast path, parsing the course data Skool embeds in
+	// the page. It's also the source of a course tree that has no videos.
+	sourceNextData
+	// sourceRegex is the fallback, scanning the whole page for video links
+	sourceRegex
+)
+
+// String returns the name used in logs and the download report
+func (s extractionSource) String() string {
+	switch s {
+	case sourceNextData:
+		return "next-data"
+	case sourceRegex:
+		return "regex"
+	default:
+		return "none"
+	}
+}
+
+// MarshalText writes the source by name in the JSON report
+func (s extractionSource) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText reads a source written by MarshalText
+func (s *extractionSource) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "next-data":
+		*s = sourceNextData
+	case "regex":
+		*s = sourceRegex
+	case "none":
+		*s = sourceNone
+	default:
+		return fmt.Errorf("unknown extraction source %q", text)
+	}
+	return nil
+}
+
+// extractionSummary is how the videos of one scraped URL were found
+type extractionSummary struct {
+	URL    string           `json:"url"`
+	Source extractionSource `json:"source"`
+	Videos int              `json:"videos"`
+}
+
+// errNoCourseData is returned when a scrape found no videos and the page had
+// no course data, which usually means the session isn't signed in or the
+// page didn't finish loading
+var errNoCourseData = errors.New("no videos found and the page had no course data. " +
+	"Check your authentication and the URL, or raise -wait if the page loads slowly")
+
+// noVideosError returns the error for a scrape that found no videos, or nil
+// when the course was read and simply has no video lessons
+func noVideosError(source extractionSource) error {
+	if source == sourceNextData {
+		return nil
+	}
+	return errNoCourseData
+}
@@ -0,0 +1,53 @@
+package skool
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestExtractVideos_Source(t *testing.T) {
+	previous := console
+	console = newLogger(levelQuiet, io.Discard)
+	defer func() {
+		console = previous
+	}()
+
+	nextData := `<script id="__NEXT_DATA__" type="application/json">{"props":{"pageProps":{"course":{"course":{"id":"c1","unitType":"course"},"children":[{"course":{"id":"m1","unitType":"module","metadata":{"title":"Welcome","videoLink":"https://www.loom.com/share/abc123"}}}]}}}}</script>`
+	tests := []struct {
+		name   string
+		html   string
+		videos int
+		want   extractionSource
+	}{
+		{"next data", nextData, 1, sourceNextData},
+		{"regex only", `<a href="https://www.loom.com/share/abc123">Video</a>`, 1, sourceRegex},
+		{"next data without videos", `<script id="__NEXT_DATA__" type="application/json">{"props":{}}</script><iframe src="https://www.youtube.com/embed/dQw4w9WgXcQ"></iframe>`, 1, sourceRegex},
+		{"course without videos", `<script id="__NEXT_DATA__" type="application/json">{"props":{"pageProps":{"course":{"course":{"id":"c1","unitType":"course"},"children":[{"course":{"id":"m1","unitType":"module","metadata":{"title":"Read me"}}}]}}}}</script>`, 0, sourceNextData},
+		{"next data without a course", `<script id="__NEXT_DATA__" type="application/json">{"props":{"pageProps":{}}}</script>`, 0, sourceNone},
+		{"nothing", `<p>No videos here</p>`, 0, sourceNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			videos, source := extractVideos(tt.html)
+			if source != tt.want {
+				t.Errorf("extractVideos() source = %s, want %s", source, tt.want)
+			}
+			if len(videos) != tt.videos {
+				t.Errorf("extractVideos() found %d video(s), want %d", len(videos), tt.videos)
+			}
+		})
+	}
+}
+
+func TestNoVideosError(t *testing.T) {
+	if err := noVideosError(sourceNextData); err != nil {
+		t.Errorf("Expected a course without videos not to be an error, got %v", err)
+	}
+	for _, source := range []extractionSource{sourceNone, sourceRegex} {
+		if err := noVideosError(source); !errors.Is(err, errNoCourseData) {
+			t.Errorf("noVideosError(%s) = %v, want errNoCourseData", source, err)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package skool
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// feedModule is the module given to videos posted in the community feed, so
+// {module} in -filename-template keeps them apart from classroom lessons
+const feedModule = "Community feed"
+
+// feedScrolls caps how often the feed is scrolled to load older posts
+const feedScrolls = 10
+
+// feedScrollDelay is how long to wait for more posts after each scroll
+const feedScrollDelay = 2 * time.Second
+
+// scrollHeightJS returns the height of the page, which grows as posts load
+const scrollHeightJS = `document.body.scrollHeight`
+
+// scrollToBottomJS scrolls to the end of the page so the feed loads more posts
+const scrollToBottomJS = `window.scrollTo(0, document.body.scrollHeight)`
+
+// feedVideos returns the videos linked in posts on a rendered feed page. Posts
+// aren't part of a course tree, so only the regex extractor applies.
+func feedVideos(html string) []VideoEntry {
+	videos := findVideoLinks(html)
+	for i := range videos {
+		videos[i].Module = feedModule
+	}
+	return videos
+}
+
+// scrollFeed scrolls to the bottom of the page until it stops growing or
+// rounds is reached, and returns how many scrolls loaded more posts
+func scrollFeed(ctx context.Context, rounds int, delay time.Duration) (int, error) {
+	var height int
+	if err := chromedp.Run(ctx, chromedp.Evaluate(scrollHeightJS, &height)); err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < rounds; i++ {
+		var newHeight int
+		err := chromedp.Run(ctx,
+			chromedp.Evaluate(scrollToBottomJS, nil),
+			human.Sleep(delay),
+			chromedp.Evaluate(scrollHeightJS, &newHeight),
+		)
+		if err != nil {
+			return i, err
+		}
+		if newHeight <= height {
+			return i, nil
+		}
+		height = newHeight
+	}
+	return rounds, nil
+}
+
+// scrapeFeed opens the community feed of the classroom's community, scrolls
+// through the posts and returns the videos linked in them
+func scrapeFeed(ctx context.Context, config Config) ([]VideoEntry, error) {
+	parsed, err := parseSkoolURL(config.SkoolURL)
+	if err != nil {
+		return nil, err
+	}
+	feedURL := parsed.CommunityURL()
+
+	console.Info("Navigating to community feed:", feedURL)
+	stopTimer := timings.Start("feed navigation")
+	err = chromedp.Run(ctx,
+		chromedp.Navigate(feedURL),
+		waitReady(pageReadyJS, time.Duration(config.WaitTime)*time.Second),
+		human.Interact(),
+	)
+	if err != nil {
+		stopTimer()
+		return nil, fmt.Errorf("failed to navigate to community feed: %v", err)
+	}
+
+	scrolls, err := scrollFeed(ctx, feedScrolls, feedScrollDelay)
+	stopTimer()
+	if err != nil {
+		// Whatever loaded before the failure is still worth extracting
+		console.Warningf("Stopped scrolling the feed: %v", err)
+	}
+	console.Debugf("Scrolled the feed %d time(s)", scrolls)
+
+	var html string
+	if err := chromedp.Run(ctx, chromedp.OuterHTML("html", &html)); err != nil {
+		return nil, fmt.Errorf("failed to read community feed: %v", err)
+	}
+
+	videos := feedVideos(html)
+	console.Infof("Extracted %d video(s) from the community feed", len(videos))
+	return videos, nil
+}
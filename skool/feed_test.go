@@ -0,0 +1,28 @@
+package skool
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFeedVideos(t *testing.T) {
+	previous := console
+	console = newLogger(levelQuiet, io.Discard)
+	defer func() {
+		console = previous
+	}()
+
+	html := readFixture(t, filepath.Join("testdata", "feed", "community_feed.html"))
+	videos := feedVideos(html)
+
+	// The repeated Loom link is kept once and the folder link is left out
+	expected := []VideoEntry{
+		{URL: "https://www.loom.com/share/0a1b2c3d4e5f60718293a4b5c6d7e8f9", Module: feedModule, Platform: platformLoom, ID: "0a1b2c3d4e5f60718293a4b5c6d7e8f9"},
+		{URL: "https://www.youtube.com/watch?v=M7lc1UVf-VE", Module: feedModule, Platform: platformYouTube, ID: "M7lc1UVf-VE"},
+	}
+	if !reflect.DeepEqual(videos, expected) {
+		t.Errorf("feedVideos() = %+v, want %+v", videos, expected)
+	}
+}
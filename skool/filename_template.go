@@ -0,0 +1,41 @@
+package skool
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// defaultFilenameTemplate is the yt-dlp output template used when -filename-template isn't set
+const defaultFilenameTemplate = "%(title)s.%(ext)s"
+
+// Placeholders filled in from the course tree before the template is handed to yt-dlp
+const (
+	placeholderModule = "{module}"
+	placeholderLesson = "{lesson}"
+)
+
+// expandFilenameTemplate substitutes {module} and {lesson} for a video. Values
+// are made safe as path segments and escaped for yt-dlp; an unknown lesson
+// title falls back to the video's own title.
+func expandFilenameTemplate(template string, video VideoEntry) string {
+	module := ""
+	if video.Module != "" {
+		module = escapeOutputTemplate(sanitizePathSegment(video.Module))
+	}
+	lesson := "%(title)s"
+	if video.Title != "" && video.Title != untitledLesson {
+		lesson = escapeOutputTemplate(sanitizePathSegment(video.Title))
+	}
+
+	return strings.NewReplacer(placeholderModule, module, placeholderLesson, lesson).Replace(template)
+}
+
+// escapeOutputTemplate escapes literal text for a yt-dlp output template
+func escapeOutputTemplate(s string) string {
+	return strings.ReplaceAll(s, "%", "%%")
+}
+
+// hasExtensionPlaceholder reports whether template ends the file name with yt-dlp's extension field
+func hasExtensionPlaceholder(template string) bool {
+	return strings.Contains(filepath.Base(filepath.FromSlash(template)), "%(ext)s")
+}
@@ -0,0 +1,61 @@
+package skool
+
+import "testing"
+
+func TestExpandFilenameTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		video    VideoEntry
+		expected string
+	}{
+		{
+			name:     "Default template is passed through",
+			template: defaultFilenameTemplate,
+			video:    VideoEntry{Title: "Welcome", Module: "Getting Started"},
+			expected: defaultFilenameTemplate,
+		},
+		{
+			name:     "Module and lesson",
+			template: "{module}/{lesson}.%(ext)s",
+			video:    VideoEntry{Title: "Welcome", Module: "Getting Started"},
+			expected: "Getting Started/Welcome.%(ext)s",
+		},
+		{
+			name:     "Unsafe characters and percent signs",
+			template: "{module}/{lesson} [%(id)s].%(ext)s",
+			video:    VideoEntry{Title: "100% done: part 1/2", Module: "Q&A?"},
+			expected: "Q&A_/100%% done_ part 1_2 [%(id)s].%(ext)s",
+		},
+		{
+			name:     "Unknown lesson falls back to the video title",
+			template: "{module}/{lesson}.%(ext)s",
+			video:    VideoEntry{},
+			expected: "/%(title)s.%(ext)s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expandFilenameTemplate(tt.template, tt.video); got != tt.expected {
+				t.Errorf("expandFilenameTemplate() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHasExtensionPlaceholder(t *testing.T) {
+	tests := map[string]bool{
+		defaultFilenameTemplate:           true,
+		"{module}/{lesson}.%(ext)s":       true,
+		"%(title)s":                       false,
+		"%(ext)s/%(title)s":               false,
+		"%(upload_date)s - %(title)s.mp4": false,
+	}
+
+	for template, expected := range tests {
+		if got := hasExtensionPlaceholder(template); got != expected {
+			t.Errorf("hasExtensionPlaceholder(%q) = %v, want %v", template, got, expected)
+		}
+	}
+}
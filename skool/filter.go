@@ -0,0 +1,108 @@
+package skool
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// videoPlatforms are the names -platforms accepts
+var videoPlatforms = []videoPlatform{platformLoom, platformYouTube, platformDrive, platformDirect}
+
+// platformNames returns the names of videoPlatforms, comma separated
+func platformNames() string {
+	names := make([]string, len(videoPlatforms))
+	for i, platform := range videoPlatforms {
+		names[i] = string(platform)
+	}
+	return strings.Join(names, ", ")
+}
+
+// titleFilter selects lessons by title for -include and -exclude
+type titleFilter struct {
+	include *regexp.Regexp
+	exclude *regexp.Regexp
+}
+
+// newTitleFilter compiles the -include and -exclude patterns, returning nil
+// when neither is set
+func newTitleFilter(include, exclude string) (*titleFilter, error) {
+	if include == "" && exclude == "" {
+		return nil, nil
+	}
+
+	f := &titleFilter{}
+	var err error
+	if include != "" {
+		if f.include, err = regexp.Compile(include); err != nil {
+			return nil, fmt.Errorf("invalid -include pattern: %v", err)
+		}
+	}
+	if exclude != "" {
+		if f.exclude, err = regexp.Compile(exclude); err != nil {
+			return nil, fmt.Errorf("invalid -exclude pattern: %v", err)
+		}
+	}
+	return f, nil
+}
+
+// Match reports whether a lesson with the given title should be downloaded.
+// Exclude wins over include.
+func (f *titleFilter) Match(title string) bool {
+	if f.exclude != nil && f.exclude.MatchString(title) {
+		return false
+	}
+	return f.include == nil || f.include.MatchString(title)
+}
+
+// filterVideos returns the videos whose title matches f, along with how many
+// were removed
+func filterVideos(videos []VideoEntry, f *titleFilter) ([]VideoEntry, int) {
+	var kept []VideoEntry
+	for _, v := range videos {
+		if f.Match(v.Title) {
+			kept = append(kept, v)
+		}
+	}
+	return kept, len(videos) - len(kept)
+}
+
+// parsePlatforms reads the comma separated -platforms list, returning nil
+// when it's empty so every platform is kept
+func parsePlatforms(list string) (map[videoPlatform]bool, error) {
+	if strings.TrimSpace(list) == "" {
+		return nil, nil
+	}
+
+	platforms := make(map[videoPlatform]bool)
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		known := false
+		for _, platform := range videoPlatforms {
+			if videoPlatform(name) == platform {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown platform %q in -platforms, use one or more of %s", name, platformNames())
+		}
+		platforms[videoPlatform(name)] = true
+	}
+	return platforms, nil
+}
+
+// filterPlatforms returns the videos hosted on one of platforms, along with
+// how many were removed
+func filterPlatforms(videos []VideoEntry, platforms map[videoPlatform]bool) ([]VideoEntry, int) {
+	var kept []VideoEntry
+	for _, v := range videos {
+		if platforms[v.Platform] {
+			kept = append(kept, v)
+		}
+	}
+	return kept, len(videos) - len(kept)
+}
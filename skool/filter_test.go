@@ -0,0 +1,146 @@
+package skool
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilterVideos(t *testing.T) {
+	videos := []VideoEntry{
+		{URL: "https://www.loom.com/share/a", Title: "Module 1 - Intro"},
+		{URL: "https://www.loom.com/share/b", Title: "Module 1 - Q&A Recording"},
+		{URL: "https://www.loom.com/share/c", Title: "Module 2 - Deep Dive"},
+		{URL: "https://www.loom.com/share/d", Title: "Bonus: Q&A"},
+		{URL: "https://www.loom.com/share/e"},
+	}
+
+	tests := []struct {
+		name        string
+		include     string
+		exclude     string
+		expected    []string
+		wantRemoved int
+	}{
+		{
+			name:        "Include only",
+			include:     "^Module 1",
+			expected:    []string{"https://www.loom.com/share/a", "https://www.loom.com/share/b"},
+			wantRemoved: 3,
+		},
+		{
+			name:        "Exclude only",
+			exclude:     "Q&A",
+			expected:    []string{"https://www.loom.com/share/a", "https://www.loom.com/share/c", "https://www.loom.com/share/e"},
+			wantRemoved: 2,
+		},
+		{
+			name:        "Exclude wins over include",
+			include:     "Module",
+			exclude:     "(?i)q&a",
+			expected:    []string{"https://www.loom.com/share/a", "https://www.loom.com/share/c"},
+			wantRemoved: 3,
+		},
+		{
+			name:        "Case-insensitive include",
+			include:     "(?i)deep dive",
+			expected:    []string{"https://www.loom.com/share/c"},
+			wantRemoved: 4,
+		},
+		{
+			name:        "Nothing matches",
+			include:     "Module 9",
+			expected:    nil,
+			wantRemoved: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newTitleFilter(tt.include, tt.exclude)
+			if err != nil {
+				t.Fatalf("newTitleFilter() error = %v", err)
+			}
+
+			kept, removed := filterVideos(videos, f)
+			if got := videoURLs(kept); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("filterVideos() = %v, want %v", got, tt.expected)
+			}
+			if removed != tt.wantRemoved {
+				t.Errorf("filterVideos() removed = %d, want %d", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestNewTitleFilter(t *testing.T) {
+	if f, err := newTitleFilter("", ""); f != nil || err != nil {
+		t.Errorf("newTitleFilter(\"\", \"\") = %v, %v, want nil, nil", f, err)
+	}
+	if _, err := newTitleFilter("(unclosed", ""); err == nil {
+		t.Error("Expected error for invalid -include pattern")
+	}
+	if _, err := newTitleFilter("", "[z-a]"); err == nil {
+		t.Error("Expected error for invalid -exclude pattern")
+	}
+}
+
+func TestFilterPlatforms(t *testing.T) {
+	videos := []VideoEntry{
+		{URL: "https://www.loom.com/share/a", Platform: platformLoom},
+		{URL: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", Platform: platformYouTube},
+		{URL: "https://drive.google.com/file/d/abc/view", Platform: platformDrive},
+		{URL: "https://www.loom.com/share/b", Platform: platformLoom},
+	}
+
+	tests := []struct {
+		name        string
+		platforms   string
+		expected    []string
+		wantRemoved int
+	}{
+		{
+			name:        "Loom only",
+			platforms:   "loom",
+			expected:    []string{"https://www.loom.com/share/a", "https://www.loom.com/share/b"},
+			wantRemoved: 2,
+		},
+		{
+			name:        "Several, with spaces and capitals",
+			platforms:   " YouTube , drive",
+			expected:    []string{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", "https://drive.google.com/file/d/abc/view"},
+			wantRemoved: 2,
+		},
+		{
+			name:        "Nothing hosted there",
+			platforms:   "direct",
+			expected:    nil,
+			wantRemoved: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			platforms, err := parsePlatforms(tt.platforms)
+			if err != nil {
+				t.Fatalf("parsePlatforms() error = %v", err)
+			}
+
+			kept, removed := filterPlatforms(videos, platforms)
+			if got := videoURLs(kept); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("filterPlatforms() = %v, want %v", got, tt.expected)
+			}
+			if removed != tt.wantRemoved {
+				t.Errorf("filterPlatforms() removed = %d, want %d", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+func TestParsePlatforms(t *testing.T) {
+	if platforms, err := parsePlatforms(""); platforms != nil || err != nil {
+		t.Errorf("parsePlatforms(\"\") = %v, %v, want nil, nil", platforms, err)
+	}
+	if _, err := parsePlatforms("loom,vimeo"); err == nil {
+		t.Error("Expected error for unknown platform vimeo")
+	}
+}
@@ -0,0 +1,149 @@
+package skool
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fixturesDir holds recorded classroom pages, each with a __NEXT_DATA__ payload
+// captured from (or modelled on) a real Skool layout
+const fixturesDir = "testdata/nextdata"
+
+// fixtureExpectation describes what the extraction pipeline should find in a fixture
+type fixtureExpectation struct {
+	videos      int  // video URLs returned by extractLoomURLs
+	pending     int  // lessons with a placeholder videoLink
+	unsupported int  // lessons whose videoLink isn't a supported host
+	collapsed   int  // sets without loaded children
+	paywall     bool // a join or upgrade page instead of the classroom
+	source      extractionSource
+
+	// For pages opened with ?md=, the lesson or set ID and the videos that
+	// scraping just that ID should return
+	md     string
+	scoped int
+}
+
+// fixtureExpectations must list every fixture in fixturesDir, so a new
+// recording can't be added without asserting what it contains
+var fixtureExpectations = map[string]fixtureExpectation{
+	"basic_course.html":      {videos: 3, source: sourceNextData},
+	"placeholders.html":      {videos: 2, pending: 2, source: sourceNextData},
+	"collapsed_modules.html": {videos: 1, collapsed: 2, source: sourceNextData},
+	"no_next_data.html":      {videos: 2, source: sourceRegex},
+	"lesson_embeds.html":     {videos: 3, source: sourceNextData},
+	"unsupported_links.html": {videos: 1, unsupported: 1, source: sourceNextData},
+	"module_page.html":       {videos: 3, pending: 1, collapsed: 1, md: "s2", scoped: 2, source: sourceNextData},
+	"paywall.html":           {paywall: true, source: sourceNextData},
+}
+
+// readFixture returns the content of a recorded page
+func readFixture(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	return string(content)
+}
+
+// runExtractionFixture runs the full extraction pipeline against a recorded page
+func runExtractionFixture(t *testing.T, path, md string) (urls []string, skipped skippedLessons, collapsed int, scoped []string) {
+	t.Helper()
+
+	html := readFixture(t, path)
+
+	urls, _ = extractLoomURLs(html)
+	if nextData, err := extractNextDataJSON(html); err == nil {
+		_, skipped = walkNextDataVideos(nextData)
+		_, collapsed = courseNodeIDs(nextData)
+		if md != "" {
+			videos, _, ok := lessonVideos(nextData, md)
+			if !ok {
+				t.Fatalf("Lesson or set %s not found in fixture", md)
+			}
+			scoped = videoURLs(videos)
+		}
+	}
+	return urls, skipped, collapsed, scoped
+}
+
+func TestExtractionFixtures(t *testing.T) {
+	previous := console
+	console = newLogger(levelQuiet, io.Discard)
+	defer func() {
+		console = previous
+	}()
+
+	paths, err := filepath.Glob(filepath.Join(fixturesDir, "*.html"))
+	if err != nil {
+		t.Fatalf("Failed to list fixtures: %v", err)
+	}
+	if len(paths) == 0 {
+		t.Fatalf("No fixtures found in %s", fixturesDir)
+	}
+
+	for _, path := range paths {
+		name := filepath.Base(path)
+		t.Run(name, func(t *testing.T) {
+			expected, ok := fixtureExpectations[name]
+			if !ok {
+				t.Fatalf("Fixture %s has no entry in fixtureExpectations", name)
+			}
+
+			urls, skipped, collapsed, scoped := runExtractionFixture(t, path, expected.md)
+
+			if len(urls) != expected.videos {
+				t.Errorf("Expected %d video(s), got %d: %v", expected.videos, len(urls), urls)
+			}
+			if len(skipped.Pending) != expected.pending {
+				t.Errorf("Expected %d pending lesson(s), got %d: %v", expected.pending, len(skipped.Pending), skipped.Pending)
+			}
+			if len(skipped.Unsupported) != expected.unsupported {
+				t.Errorf("Expected %d unsupported lesson(s), got %d: %v", expected.unsupported, len(skipped.Unsupported), skipped.Unsupported)
+			}
+			if collapsed != expected.collapsed {
+				t.Errorf("Expected %d collapsed set(s), got %d", expected.collapsed, collapsed)
+			}
+			if len(scoped) != expected.scoped {
+				t.Errorf("Expected %d video(s) in %s, got %d: %v", expected.scoped, expected.md, len(scoped), scoped)
+			}
+			if _, source := extractLoomURLs(readFixture(t, path)); source != expected.source {
+				t.Errorf("Expected videos from %s, got %s", expected.source, source)
+			}
+			if marker := detectPaywall(readFixture(t, path)); (marker != "") != expected.paywall {
+				t.Errorf("Expected paywall %v, got marker %q", expected.paywall, marker)
+			}
+
+			// Every video should know its platform and ID, once each
+			videos, _ := extractVideos(readFixture(t, path))
+			keys := make(map[string]bool)
+			for _, v := range videos {
+				if v.Platform == "" || v.ID == "" {
+					t.Errorf("Video without platform or ID: %+v", v)
+				}
+				if keys[v.Key()] {
+					t.Errorf("Duplicate video %s", v.Key())
+				}
+				keys[v.Key()] = true
+			}
+
+			// Every URL should come out normalized
+			for _, u := range urls {
+				if !strings.HasPrefix(u, "https://www.loom.com/share/") && !strings.HasPrefix(u, "https://www.youtube.com/watch?v=") {
+					t.Errorf("URL not normalized: %s", u)
+				}
+			}
+		})
+	}
+
+	for name := range fixtureExpectations {
+		if _, err := os.Stat(filepath.Join(fixturesDir, name)); err != nil {
+			t.Errorf("Expected fixture %s is missing: %v", name, err)
+		}
+	}
+}
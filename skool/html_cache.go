@@ -0,0 +1,65 @@
+package skool
+
+import (
+	"fmt"
+	"os"
+)
+
+// saveHTMLCache writes the classroom HTML to -cache-html so later runs can
+// extract from it with -from-html. A failure is only a warning.
+func saveHTMLCache(path, html string) {
+	if err := os.WriteFile(path, []byte(html), 0644); err != nil {
+		console.Warningf("Failed to write -cache-html file: %v", err)
+		return
+	}
+	console.Info("Classroom HTML written to:", path)
+}
+
+// validateFromHTML rejects the flags that need a live browser, which
+// -from-html never launches
+func validateFromHTML(config Config) error {
+	browserFlags := []struct {
+		name string
+		set  bool
+	}{
+		{"-check-auth", config.CheckAuth},
+		{"-manual-login", config.ManualLogin},
+		{"-deep", config.Deep},
+		{"-include-feed", config.IncludeFeed},
+		{"-capture-network", config.CaptureNetwork},
+		{"-cache-html", config.CacheHTML != ""},
+		{"-export-cookies", config.ExportCookies != ""},
+		{"-save-cookies", config.SaveCookies != ""},
+	}
+	for _, flag := range browserFlags {
+		if flag.set {
+			return fmt.Errorf("-from-html reads a saved page without a browser, it cannot be combined with %s", flag.name)
+		}
+	}
+	return nil
+}
+
+// scrapeHTMLFile extracts the videos from a classroom page saved with
+// -cache-html (or -debug-html), without a browser or network access
+func scrapeHTMLFile(config Config) (*scrapeResult, error) {
+	content, err := os.ReadFile(config.FromHTML)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read -from-html file: %v", err)
+	}
+	html := string(content)
+	console.Info("Extracting videos from saved page:", config.FromHTML)
+
+	result := &scrapeResult{Course: extractCourseMetadata(nil, config.SkoolURL)}
+	if nextData, err := extractNextDataJSON(html); err == nil {
+		result.Course = extractCourseMetadata(nextData, config.SkoolURL)
+		if _, collapsed := courseNodeIDs(nextData); collapsed > 0 {
+			console.Warningf("%d module(s) appear collapsed and may hide lessons; -deep needs a live browser, so scrape without -from-html to visit them", collapsed)
+		}
+	}
+
+	result.Videos, result.Source = extractVideos(html)
+	if len(result.Videos) == 0 {
+		console.Warning("No videos found on the page.")
+	}
+	return result, nil
+}
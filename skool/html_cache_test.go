@@ -0,0 +1,200 @@
+package skool
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestScrapeHTMLFile(t *testing.T) {
+	previous := console
+	console = newLogger(levelQuiet, io.Discard)
+	defer func() {
+		console = previous
+	}()
+
+	config := Config{FromHTML: filepath.Join(fixturesDir, "basic_course.html")}
+	result, err := scrapeVideos(context.Background(), config)
+	if err != nil {
+		t.Fatalf("scrapeVideos() with -from-html error: %v", err)
+	}
+
+	expected := []string{
+		"https://www.loom.com/share/abc123def456",
+		"https://www.loom.com/share/fed654cba321",
+		"https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+	}
+	if got := videoURLs(result.Videos); !reflect.DeepEqual(got, expected) {
+		t.Errorf("URLs = %v, want %v", got, expected)
+	}
+	if result.Source != sourceNextData {
+		t.Errorf("Source = %v, want %v", result.Source, sourceNextData)
+	}
+}
+
+func TestScrapeHTMLFile_Cached(t *testing.T) {
+	previous := console
+	console = newLogger(levelQuiet, io.Discard)
+	defer func() {
+		console = previous
+	}()
+
+	content, err := os.ReadFile(filepath.Join(fixturesDir, "unsupported_links.html"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	cached := filepath.Join(t.TempDir(), "classroom.html")
+	saveHTMLCache(cached, string(content))
+
+	result, err := scrapeHTMLFile(Config{FromHTML: cached})
+	if err != nil {
+		t.Fatalf("scrapeHTMLFile() error: %v", err)
+	}
+	expected, _ := extractVideos(string(content))
+	if !reflect.DeepEqual(result.Videos, expected) {
+		t.Errorf("Videos from the cached page = %+v, want %+v", result.Videos, expected)
+	}
+
+	if _, err := scrapeHTMLFile(Config{FromHTML: filepath.Join(t.TempDir(), "missing.html")}); err == nil {
+		t.Error("Expected an error for a missing -from-html file")
+	}
+}
+
+func TestRun_PrintURLsFromHTML(t *testing.T) {
+	previous := console
+	console = newLogger(levelQuiet, io.Discard)
+	defer func() {
+		console = previous
+	}()
+
+	// An earlier run downloaded one of the videos
+	outputDir := t.TempDir()
+	classroom := "https://www.skool.com/test/classroom"
+	state, err := loadStateStore(filepath.Join(outputDir, stateFileName))
+	if err != nil {
+		t.Fatalf("loadStateStore() error: %v", err)
+	}
+	state.MarkDownloaded(classroom, VideoEntry{URL: "https://www.loom.com/share/fed654cba321"})
+	if err := state.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	config, err := parseArgs(flag.NewFlagSet("test", flag.ContinueOnError), []string{
+		"-url", classroom,
+		"-from-html", filepath.Join(fixturesDir, "basic_course.html"),
+		"-output", outputDir,
+		"-print-urls",
+	})
+	if err != nil {
+		t.Fatalf("parseArgs() error: %v", err)
+	}
+
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error: %v", err)
+	}
+	os.Stdout = w
+	runErr := Run(config)
+	_ = w.Close()
+	os.Stdout = stdout
+	printed, _ := io.ReadAll(r)
+	if runErr != nil {
+		t.Fatalf("Run() error: %v", runErr)
+	}
+
+	// -print-urls lists every video, including the downloaded one
+	for _, url := range []string{"https://www.loom.com/share/abc123def456", "https://www.loom.com/share/fed654cba321", "https://www.youtube.com/watch?v=dQw4w9WgXcQ"} {
+		if !strings.Contains(string(printed), url+"\n") {
+			t.Errorf("Expected %s in the printed URLs, got:\n%s", url, printed)
+		}
+	}
+
+	// and leaves the output directory alone
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		t.Fatalf("ReadDir() error: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != stateFileName {
+		t.Errorf("Expected only the state file in the output directory, got %v", entries)
+	}
+}
+
+func TestRun_OverwriteSkipsStateFilter(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake yt-dlp is a shell script")
+	}
+	previous := console
+	console = newLogger(levelQuiet, io.Discard)
+	defer func() {
+		console = previous
+	}()
+
+	tests := []struct {
+		name       string
+		overwrite  bool
+		downloaded []string
+	}{
+		{name: "Default", overwrite: false, downloaded: []string{"https://www.loom.com/share/abc123def456", "https://www.youtube.com/watch?v=dQw4w9WgXcQ"}},
+		{name: "Overwrite", overwrite: true, downloaded: []string{"https://www.loom.com/share/abc123def456", "https://www.loom.com/share/fed654cba321", "https://www.youtube.com/watch?v=dQw4w9WgXcQ"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The fake yt-dlp logs the URL of every download, its last argument
+			dir := t.TempDir()
+			log := filepath.Join(dir, "downloads.log")
+			fakeYtDlp := filepath.Join(dir, "yt-dlp")
+			script := "#!/bin/sh\ncase \"$1\" in --version) echo 2099.01.01; exit 0;; esac\nfor last; do :; done\necho \"$last\" >> " + log + "\n"
+			if err := os.WriteFile(fakeYtDlp, []byte(script), 0755); err != nil {
+				t.Fatalf("Failed to create fake yt-dlp: %v", err)
+			}
+
+			// An earlier run downloaded one of the videos
+			outputDir := filepath.Join(dir, "out")
+			classroom := "https://www.skool.com/test/classroom"
+			state, err := loadStateStore(filepath.Join(outputDir, stateFileName))
+			if err != nil {
+				t.Fatalf("loadStateStore() error: %v", err)
+			}
+			state.MarkDownloaded(classroom, VideoEntry{URL: "https://www.loom.com/share/fed654cba321"})
+			if err := os.MkdirAll(outputDir, 0755); err != nil {
+				t.Fatalf("MkdirAll() error: %v", err)
+			}
+			if err := state.Save(); err != nil {
+				t.Fatalf("Save() error: %v", err)
+			}
+
+			args := []string{
+				"-url", classroom,
+				"-from-html", filepath.Join(fixturesDir, "basic_course.html"),
+				"-output", outputDir,
+				"-ytdlp", fakeYtDlp,
+			}
+			if tt.overwrite {
+				args = append(args, "-overwrite")
+			}
+			config, err := parseArgs(flag.NewFlagSet("test", flag.ContinueOnError), args)
+			if err != nil {
+				t.Fatalf("parseArgs() error: %v", err)
+			}
+			if err := Run(config); err != nil {
+				t.Fatalf("Run() error: %v", err)
+			}
+
+			content, err := os.ReadFile(log)
+			if err != nil {
+				t.Fatalf("ReadFile() error: %v", err)
+			}
+			if got := strings.Fields(string(content)); !reflect.DeepEqual(got, tt.downloaded) {
+				t.Errorf("Downloaded %v, want %v", got, tt.downloaded)
+			}
+		})
+	}
+}
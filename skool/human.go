@@ -0,0 +1,101 @@
+package skool
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/input"
+	"github.com/chromedp/chromedp"
+)
+
+// Bounds of the random pauses -human adds between login steps
+const (
+	humanPauseMin = 200 * time.Millisecond
+	humanPauseMax = 900 * time.Millisecond
+)
+
+// humanizer varies the browser's timing with -human: waits get random
+// jitter, login steps get short pauses and pages get a few mouse moves before
+// extraction, which makes the scraper harder to fingerprint. Without -human
+// waits are used as-is and the extra actions do nothing. It's safe for
+// concurrent use by -tabs.
+type humanizer struct {
+	mu      sync.Mutex
+	enabled bool
+	rng     *rand.Rand
+}
+
+// human is the humanizer of the current run, set up from -human and -human-seed
+var human = newHumanizer(false, 0)
+
+// newHumanizer returns a humanizer drawing from seed, or from the clock when
+// seed is 0
+func newHumanizer(enabled bool, seed int64) *humanizer {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &humanizer{enabled: enabled, rng: rand.New(rand.NewSource(seed))}
+}
+
+// float returns a random number in [0, 1)
+func (h *humanizer) float() float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.rng.Float64()
+}
+
+// Jitter returns d stretched by a random factor between 0.75 and 1.5
+func (h *humanizer) Jitter(d time.Duration) time.Duration {
+	if !h.enabled {
+		return d
+	}
+	return time.Duration(float64(d) * (0.75 + 0.75*h.float()))
+}
+
+// Sleep waits for d with jitter
+func (h *humanizer) Sleep(d time.Duration) chromedp.Action {
+	return chromedp.Sleep(h.Jitter(d))
+}
+
+// Pause waits briefly, like someone moving on to the next field
+func (h *humanizer) Pause() chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		if !h.enabled {
+			return nil
+		}
+		pause := humanPauseMin + time.Duration(h.float()*float64(humanPauseMax-humanPauseMin))
+		return chromedp.Sleep(pause).Do(ctx)
+	})
+}
+
+// Interact moves the mouse across the page a few times and scrolls a little
+func (h *humanizer) Interact() chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		if !h.enabled {
+			return nil
+		}
+		var size struct {
+			Width  float64 `json:"width"`
+			Height float64 `json:"height"`
+		}
+		if err := chromedp.Evaluate(`({width: window.innerWidth, height: window.innerHeight})`, &size).Do(ctx); err != nil {
+			return err
+		}
+
+		moves := 3 + int(h.float()*4)
+		for i := 0; i < moves; i++ {
+			x, y := h.float()*size.Width, h.float()*size.Height
+			if err := input.DispatchMouseEvent(input.MouseMoved, x, y).Do(ctx); err != nil {
+				return err
+			}
+			if err := h.Pause().Do(ctx); err != nil {
+				return err
+			}
+		}
+		return input.DispatchMouseEvent(input.MouseWheel, size.Width/2, size.Height/2).
+			WithDeltaY(100 + h.float()*400).
+			Do(ctx)
+	})
+}
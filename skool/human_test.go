@@ -0,0 +1,33 @@
+package skool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanizer_Disabled(t *testing.T) {
+	h := newHumanizer(false, 1)
+	if got := h.Jitter(2 * time.Second); got != 2*time.Second {
+		t.Errorf("Jitter() without -human = %v, want 2s", got)
+	}
+}
+
+func TestHumanizer_Jitter(t *testing.T) {
+	a, b := newHumanizer(true, 42), newHumanizer(true, 42)
+	varied := false
+	for i := 0; i < 50; i++ {
+		got := a.Jitter(2 * time.Second)
+		if got < 1500*time.Millisecond || got >= 3*time.Second {
+			t.Fatalf("Jitter(2s) = %v, want within [1.5s, 3s)", got)
+		}
+		if again := b.Jitter(2 * time.Second); again != got {
+			t.Fatalf("Expected the same seed to give the same waits, got %v and %v", got, again)
+		}
+		if got != 2*time.Second {
+			varied = true
+		}
+	}
+	if !varied {
+		t.Error("Expected -human to vary the waits")
+	}
+}
@@ -0,0 +1,69 @@
+package skool
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Minimum digits of each level of a -number-files prefix: flat courses get
+// 001, nested ones 01.03 so the prefix doesn't get too long
+const (
+	flatNumberWidth   = 3
+	nestedNumberWidth = 2
+)
+
+// childPosition returns position extended by a child's 1-based index, without
+// sharing position's backing array between siblings
+func childPosition(position []int, index int) []int {
+	return append(append([]int(nil), position...), index+1)
+}
+
+// numberVideos fills in Number for every video with a course position. Each
+// level is padded to the widest index found at that depth, so files sort in
+// course order; videos found outside the course tree stay unnumbered.
+func numberVideos(videos []VideoEntry) {
+	var widest []int
+	for _, video := range videos {
+		for depth, index := range video.Position {
+			if depth == len(widest) {
+				widest = append(widest, 0)
+			}
+			if index > widest[depth] {
+				widest[depth] = index
+			}
+		}
+	}
+
+	minWidth := nestedNumberWidth
+	if len(widest) == 1 {
+		minWidth = flatNumberWidth
+	}
+	widths := make([]int, len(widest))
+	for depth, index := range widest {
+		widths[depth] = max(minWidth, len(fmt.Sprint(index)))
+	}
+
+	for i := range videos {
+		videos[i].Number = formatLessonNumber(videos[i].Position, widths)
+	}
+}
+
+// formatLessonNumber joins the zero-padded levels of position with dots
+func formatLessonNumber(position []int, widths []int) string {
+	parts := make([]string, len(position))
+	for depth, index := range position {
+		parts[depth] = fmt.Sprintf("%0*d", widths[depth], index)
+	}
+	return strings.Join(parts, ".")
+}
+
+// numberFilename prefixes the file name of an output template with a lesson
+// number, leaving any folders in front of it alone
+func numberFilename(template, number string) string {
+	if number == "" {
+		return template
+	}
+	dir, file := filepath.Split(filepath.FromSlash(template))
+	return dir + number + " - " + file
+}
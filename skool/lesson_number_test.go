@@ -0,0 +1,108 @@
+package skool
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNumberVideos(t *testing.T) {
+	tests := []struct {
+		name      string
+		positions [][]int
+		expected  []string
+	}{
+		{"Flat course", [][]int{{1}, {2}, {10}}, []string{"001", "002", "010"}},
+		{"Flat course over 999 lessons", [][]int{{7}, {1200}}, []string{"0007", "1200"}},
+		{"Modules", [][]int{{1, 1}, {1, 3}, {2, 1}}, []string{"01.01", "01.03", "02.01"}},
+		{"Wide module", [][]int{{1, 2}, {3, 120}}, []string{"01.002", "03.120"}},
+		{"Lessons next to modules", [][]int{{1}, {2, 1}, {2, 2}, {3}}, []string{"01", "02.01", "02.02", "03"}},
+		{"Nested sets", [][]int{{1, 2, 3}, {2, 1}}, []string{"01.02.03", "02.01"}},
+		{"Outside the course tree", [][]int{{1}, nil}, []string{"001", ""}},
+		{"Nothing numbered", [][]int{nil, nil}, []string{"", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			videos := make([]VideoEntry, len(tt.positions))
+			for i, position := range tt.positions {
+				videos[i].Position = position
+			}
+			numberVideos(videos)
+
+			var got []string
+			for _, v := range videos {
+				got = append(got, v.Number)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("numberVideos() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNumberFilename(t *testing.T) {
+	tests := []struct {
+		template string
+		number   string
+		expected string
+	}{
+		{"%(title)s.%(ext)s", "001", "001 - %(title)s.%(ext)s"},
+		{"Module/Lesson.%(ext)s", "01.03", filepath.Join("Module", "01.03 - Lesson.%(ext)s")},
+		{"%(title)s.%(ext)s", "", "%(title)s.%(ext)s"},
+	}
+
+	for _, tt := range tests {
+		if got := numberFilename(tt.template, tt.number); got != tt.expected {
+			t.Errorf("numberFilename(%q, %q) = %q, want %q", tt.template, tt.number, got, tt.expected)
+		}
+	}
+}
+
+func TestOutputTemplate_NumberFiles(t *testing.T) {
+	video := VideoEntry{Title: "Setup", Module: "Getting Started", Number: "01.02"}
+	config := Config{OutputDir: "downloads", FilenameTemplate: "{module}/{lesson}.%(ext)s", NumberFiles: true}
+
+	want := filepath.Join("downloads", "Getting Started", "01.02 - Setup.%(ext)s")
+	if got := outputTemplate(video, config); got != want {
+		t.Errorf("outputTemplate() = %v, want %v", got, want)
+	}
+
+	config.NumberFiles = false
+	want = filepath.Join("downloads", "Getting Started", "Setup.%(ext)s")
+	if got := outputTemplate(video, config); got != want {
+		t.Errorf("outputTemplate() without -number-files = %v, want %v", got, want)
+	}
+}
+
+func TestWalkCourseVideos_Positions(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join(fixturesDir, "basic_course.html"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+	data, err := extractNextDataJSON(string(content))
+	if err != nil {
+		t.Fatalf("extractNextDataJSON() error = %v", err)
+	}
+
+	videos, _ := walkNextDataVideos(data)
+	numberVideos(videos)
+	var got []string
+	for _, v := range videos {
+		got = append(got, v.Number+" "+v.Title)
+	}
+	expected := []string{"01.01 Welcome", "01.02 Setup", "02.01 Overview"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Numbered videos = %q, want %q", got, expected)
+	}
+
+	// A set scraped on its own keeps its place in the whole course
+	setVideos, _, ok := lessonVideos(data, "s2")
+	if !ok || len(setVideos) == 0 {
+		t.Fatal("lessonVideos() didn't find set s2")
+	}
+	if want := []int{2, 1}; !reflect.DeepEqual(setVideos[0].Position, want) {
+		t.Errorf("Position of the set's first lesson = %v, want %v", setVideos[0].Position, want)
+	}
+}
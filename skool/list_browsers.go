@@ -0,0 +1,42 @@
+package skool
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// listBrowsers prints every browser candidate with whether it was found, the
+// resolved path, and which one auto-detection would pick. resolve is
+// resolveBrowserCandidate outside of tests.
+func listBrowsers(w io.Writer, candidates []string, resolve func(string) (string, bool)) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "CANDIDATE\tSTATUS\tPATH"); err != nil {
+		return err
+	}
+
+	selected := ""
+	for _, candidate := range candidates {
+		status, path := "not found", "-"
+		if resolved, ok := resolve(candidate); ok {
+			status, path = "found", resolved
+			if selected == "" {
+				selected = resolved
+				status = "found (selected)"
+			}
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", candidate, status, path); err != nil {
+			return err
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if selected == "" {
+		_, err := fmt.Fprintln(w, "\nNo supported browser found, install one or pass -browser=/path/to/browser")
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\nAuto-detection would use: %s\n", selected)
+	return err
+}
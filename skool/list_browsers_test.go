@@ -0,0 +1,47 @@
+package skool
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestListBrowsers(t *testing.T) {
+	installed := map[string]string{
+		"chromium":      "/usr/bin/chromium",
+		"brave-browser": "/usr/bin/brave-browser",
+	}
+	resolve := func(candidate string) (string, bool) {
+		path, ok := installed[candidate]
+		return path, ok
+	}
+
+	var buf bytes.Buffer
+	if err := listBrowsers(&buf, []string{"google-chrome", "chromium", "brave-browser"}, resolve); err != nil {
+		t.Fatalf("listBrowsers() error = %v", err)
+	}
+
+	lines := strings.Split(buf.String(), "\n")
+	for i, want := range []string{
+		"google-chrome  not found",
+		"chromium       found (selected)  /usr/bin/chromium",
+		"brave-browser  found             /usr/bin/brave-browser",
+	} {
+		if !strings.HasPrefix(lines[i+1], want) {
+			t.Errorf("Line %d = %q, want prefix %q", i+1, lines[i+1], want)
+		}
+	}
+	if !strings.Contains(buf.String(), "Auto-detection would use: /usr/bin/chromium") {
+		t.Errorf("Expected the selected browser in the output:\n%s", buf.String())
+	}
+}
+
+func TestListBrowsers_NoneFound(t *testing.T) {
+	var buf bytes.Buffer
+	if err := listBrowsers(&buf, []string{"chrome"}, func(string) (string, bool) { return "", false }); err != nil {
+		t.Fatalf("listBrowsers() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "No supported browser found") {
+		t.Errorf("Expected a no-browser message:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,44 @@
+package skool
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// listVideoFormats runs yt-dlp -F for each video and writes the format tables
+// to w, each under a header naming the video. A video that fails is reported
+// and skipped, like with -list-output.
+func listVideoFormats(w io.Writer, videos []VideoEntry, config Config) {
+	cookiesFile, cleanup, err := prepareYtDlpCookies(config)
+	if err != nil {
+		console.Error(err)
+		return
+	}
+	defer cleanup()
+
+	config.ListFormats = true
+	for i, video := range videos {
+		if video.Title != "" {
+			_, _ = fmt.Fprintf(w, "[%d/%d] %s (%s)\n", i+1, len(videos), video.Title, video.URL)
+		} else {
+			_, _ = fmt.Fprintf(w, "[%d/%d] %s\n", i+1, len(videos), video.URL)
+		}
+
+		args := buildYtDlpArgs(video, cookiesFile, "", config)
+		console.Debug("Running:", config.YtDlpPath, strings.Join(args, " "))
+		cmd := exec.Command(config.YtDlpPath, args...)
+		cmd.Stdout = w
+		tail := &stderrTail{}
+		cmd.Stderr = io.MultiWriter(os.Stderr, tail)
+		if err := cmd.Run(); err != nil {
+			if line := tail.Line(); line != "" {
+				err = fmt.Errorf("%v: %s", err, line)
+			}
+			console.Errorf("[%d/%d] %s: %v", i+1, len(videos), video.URL, err)
+		}
+		_, _ = fmt.Fprintln(w)
+	}
+}
@@ -0,0 +1,51 @@
+package skool
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestListVideoFormats(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake yt-dlp is a shell script")
+	}
+
+	previous := console
+	console = newLogger(levelQuiet, io.Discard)
+	defer func() {
+		console = previous
+	}()
+
+	// Prints its arguments like a format table, and fails for the second video
+	fakeYtDlp := filepath.Join(t.TempDir(), "yt-dlp")
+	script := "#!/bin/sh\nfor last; do :; done\ncase \"$last\" in *broken*) echo 'ERROR: Private video' >&2; exit 1;; esac\necho \"formats: $*\"\n"
+	if err := os.WriteFile(fakeYtDlp, []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create fake yt-dlp: %v", err)
+	}
+
+	videos := []VideoEntry{
+		{URL: "https://www.loom.com/share/abc123", Title: "Intro"},
+		{URL: "https://www.loom.com/share/broken"},
+	}
+	var out bytes.Buffer
+	listVideoFormats(&out, videos, Config{YtDlpPath: fakeYtDlp, OutputDir: "downloads"})
+
+	got := out.String()
+	for _, want := range []string{
+		"[1/2] Intro (https://www.loom.com/share/abc123)\n",
+		"--list-formats",
+		"[2/2] https://www.loom.com/share/broken\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "--print-to-file") || strings.Contains(got, "--skip-download") {
+		t.Errorf("Expected only --list-formats, got:\n%s", got)
+	}
+}
@@ -0,0 +1,117 @@
+package skool
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// logLevel controls how much output is printed
+type logLevel int
+
+const (
+	levelQuiet   logLevel = iota // errors and the final result only
+	levelNormal                  // regular progress output
+	levelVerbose                 // everything, including debug details and chromedp logs
+)
+
+// logger prints colored, prefixed messages that respect the configured level
+type logger struct {
+	level logLevel
+	out   io.Writer
+}
+
+// console is the logger used throughout the application
+var console = newLogger(levelNormal, os.Stdout)
+
+func newLogger(level logLevel, out io.Writer) *logger {
+	return &logger{level: level, out: out}
+}
+
+// enabled reports whether messages of the given level should be printed
+func (l *logger) enabled(level logLevel) bool {
+	return l.level >= level
+}
+
+func (l *logger) println(level logLevel, prefix string, a ...interface{}) {
+	if !l.enabled(level) {
+		return
+	}
+	_, _ = fmt.Fprintln(l.out, append([]interface{}{prefix}, a...)...)
+}
+
+func (l *logger) printf(level logLevel, prefix, format string, a ...interface{}) {
+	if !l.enabled(level) {
+		return
+	}
+	_, _ = fmt.Fprintf(l.out, "%s %s\n", prefix, fmt.Sprintf(format, a...))
+}
+
+func (l *logger) Info(a ...interface{}) {
+	l.println(levelNormal, prefixInfo, a...)
+}
+
+func (l *logger) Infof(format string, a ...interface{}) {
+	l.printf(levelNormal, prefixInfo, format, a...)
+}
+
+func (l *logger) Success(a ...interface{}) {
+	l.println(levelNormal, prefixSuccess, a...)
+}
+
+func (l *logger) Successf(format string, a ...interface{}) {
+	l.printf(levelNormal, prefixSuccess, format, a...)
+}
+
+func (l *logger) Warning(a ...interface{}) {
+	l.println(levelNormal, prefixWarning, a...)
+}
+
+func (l *logger) Warningf(format string, a ...interface{}) {
+	l.printf(levelNormal, prefixWarning, format, a...)
+}
+
+func (l *logger) Auth(a ...interface{}) {
+	l.println(levelNormal, prefixAuth, a...)
+}
+
+func (l *logger) Authf(format string, a ...interface{}) {
+	l.printf(levelNormal, prefixAuth, format, a...)
+}
+
+func (l *logger) Download(a ...interface{}) {
+	l.println(levelNormal, prefixDownload, a...)
+}
+
+func (l *logger) Downloadf(format string, a ...interface{}) {
+	l.printf(levelNormal, prefixDownload, format, a...)
+}
+
+func (l *logger) Debug(a ...interface{}) {
+	l.println(levelVerbose, prefixDebug, a...)
+}
+
+func (l *logger) Debugf(format string, a ...interface{}) {
+	l.printf(levelVerbose, prefixDebug, format, a...)
+}
+
+// Errors are always printed, even in quiet mode
+func (l *logger) Error(a ...interface{}) {
+	l.println(levelQuiet, prefixError, a...)
+}
+
+func (l *logger) Errorf(format string, a ...interface{}) {
+	l.printf(levelQuiet, prefixError, format, a...)
+}
+
+// Result prints the final outcome of a run, which is kept even in quiet mode
+func (l *logger) Result(a ...interface{}) {
+	l.println(levelQuiet, prefixSuccess, a...)
+}
+
+// Blank prints an empty separator line
+func (l *logger) Blank() {
+	if l.enabled(levelNormal) {
+		_, _ = fmt.Fprintln(l.out)
+	}
+}
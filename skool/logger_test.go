@@ -0,0 +1,74 @@
+package skool
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLogger_QuietSuppressesProgress(t *testing.T) {
+	var buf bytes.Buffer
+	l := newLogger(levelQuiet, &buf)
+
+	l.Info("navigating")
+	l.Warning("fallback")
+	l.Debug("details")
+	l.Blank()
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output in quiet mode, got %q", buf.String())
+	}
+
+	l.Error("failed")
+	l.Result("done")
+	out := buf.String()
+	if !strings.Contains(out, "failed") || !strings.Contains(out, "done") {
+		t.Errorf("Expected errors and result in quiet mode, got %q", out)
+	}
+}
+
+func TestLogger_NormalHidesDebug(t *testing.T) {
+	var buf bytes.Buffer
+	l := newLogger(levelNormal, &buf)
+
+	l.Debugf("hidden %d", 1)
+	if buf.Len() != 0 {
+		t.Errorf("Expected debug output to be hidden, got %q", buf.String())
+	}
+
+	l.Infof("Found %d video(s)", 3)
+	if !strings.Contains(buf.String(), prefixInfo+" Found 3 video(s)\n") {
+		t.Errorf("Unexpected info output: %q", buf.String())
+	}
+}
+
+func TestLogger_VerboseShowsDebug(t *testing.T) {
+	var buf bytes.Buffer
+	l := newLogger(levelVerbose, &buf)
+
+	l.Debug("details")
+	if !strings.Contains(buf.String(), "details") {
+		t.Errorf("Expected debug output in verbose mode, got %q", buf.String())
+	}
+}
+
+func TestConfigLogLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		expected logLevel
+	}{
+		{name: "Default", config: Config{}, expected: levelNormal},
+		{name: "Quiet", config: Config{Quiet: true}, expected: levelQuiet},
+		{name: "Verbose", config: Config{Verbose: true}, expected: levelVerbose},
+		{name: "JSON", config: Config{JSON: true}, expected: levelQuiet},
+		{name: "JSON verbose", config: Config{JSON: true, Verbose: true}, expected: levelVerbose},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.logLevel(); got != tt.expected {
+				t.Errorf("logLevel() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package skool
+
+// selectorStrategy is one way of finding a login page element
+type selectorStrategy struct {
+	name  string // shown in -verbose logs so a broken strategy is easy to spot
+	xpath string
+}
+
+// loginElement is a login page element and the strategies tried, in order,
+// to find it. Update these when Skool changes its login markup.
+type loginElement struct {
+	what       string
+	strategies []selectorStrategy
+}
+
+// Login page elements, from the most specific strategy to the most lenient
+var (
+	loginButtonElement = loginElement{"login button", []selectorStrategy{
+		{"button text", `//button[@type="button"]/span[text()="Log In"]`},
+		{"accessible name", `//*[(self::button or @role="button") and (translate(normalize-space(.), "LOGIN", "login") = "log in" or translate(@aria-label, "LOGIN", "login") = "log in")]`},
+		{"login link", `//a[contains(@href, "/login")]`},
+	}}
+	emailInputElement = loginElement{"email field", []selectorStrategy{
+		{"type or name", `//input[@type="email" or @name="email"]`},
+		{"autocomplete", `//input[@autocomplete="email" or @autocomplete="username"]`},
+		{"placeholder", `//input[contains(translate(@placeholder, "EMAIL", "email"), "email")]`},
+		{"accessible name", `//input[contains(translate(@aria-label, "EMAIL", "email"), "email")]`},
+	}}
+	passwordInputElement = loginElement{"password field", []selectorStrategy{
+		{"type or name", `//input[@type="password" or @name="password"]`},
+		{"autocomplete", `//input[@autocomplete="current-password"]`},
+		{"placeholder", `//input[contains(translate(@placeholder, "PASSWORD", "password"), "password")]`},
+		{"accessible name", `//input[contains(translate(@aria-label, "PASSWORD", "password"), "password")]`},
+	}}
+	submitButtonElement = loginElement{"login submit button", []selectorStrategy{
+		{"submit button text", `//button[@type="submit" and .//span[contains(text(), "Log") or contains(text(), "Log In") or contains(text(), "Login")]]`},
+		{"accessible name", `//form//*[(self::button or @role="button") and (contains(translate(normalize-space(.), "LOGIN", "login"), "log in") or contains(translate(@aria-label, "LOGIN", "login"), "log in"))]`},
+		{"form submit button", `//form[.//input[@type="password"]]//button[@type="submit" or not(@type)]`},
+	}}
+)
+
+// firstVisibleStrategy returns the first of el's strategies whose element is
+// visible according to visible, reporting false if none is
+func firstVisibleStrategy(el loginElement, visible func(xpath string) (bool, error)) (selectorStrategy, bool, error) {
+	for _, s := range el.strategies {
+		ok, err := visible(s.xpath)
+		if err != nil {
+			return selectorStrategy{}, false, err
+		}
+		if ok {
+			return s, true, nil
+		}
+	}
+	return selectorStrategy{}, false, nil
+}
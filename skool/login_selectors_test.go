@@ -0,0 +1,50 @@
+package skool
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFirstVisibleStrategy(t *testing.T) {
+	el := loginElement{"email field", []selectorStrategy{
+		{"type or name", "//a"},
+		{"placeholder", "//b"},
+		{"accessible name", "//c"},
+	}}
+
+	var tried []string
+	strategy, ok, err := firstVisibleStrategy(el, func(xpath string) (bool, error) {
+		tried = append(tried, xpath)
+		return xpath != "//a", nil
+	})
+	if err != nil || !ok || strategy.name != "placeholder" {
+		t.Errorf("firstVisibleStrategy() = %+v, %v, %v, want the placeholder strategy", strategy, ok, err)
+	}
+	if len(tried) != 2 {
+		t.Errorf("Expected strategies to stop at the first match, tried %v", tried)
+	}
+
+	if _, ok, err := firstVisibleStrategy(el, func(string) (bool, error) { return false, nil }); ok || err != nil {
+		t.Errorf("Expected no match, got ok = %v, err = %v", ok, err)
+	}
+
+	failure := errors.New("target closed")
+	if _, _, err := firstVisibleStrategy(el, func(string) (bool, error) { return false, failure }); !errors.Is(err, failure) {
+		t.Errorf("Expected the browser error, got %v", err)
+	}
+}
+
+func TestLoginElements_HaveFallbacks(t *testing.T) {
+	for _, el := range []loginElement{loginButtonElement, emailInputElement, passwordInputElement, submitButtonElement} {
+		if len(el.strategies) < 2 {
+			t.Errorf("Expected the %s to have fallback strategies, got %d", el.what, len(el.strategies))
+		}
+		names := make(map[string]bool)
+		for _, s := range el.strategies {
+			if s.name == "" || s.xpath == "" || names[s.name] {
+				t.Errorf("The %s has an unnamed, empty or duplicate strategy: %+v", el.what, s)
+			}
+			names[s.name] = true
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package skool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// defaultLoginWait is how long each login step waits for the page by default
+const defaultLoginWait = 15 * time.Second
+
+// Backoff between checks while waiting for a login step
+const (
+	loginPollInitialDelay = 250 * time.Millisecond
+	loginPollMaxDelay     = 2 * time.Second
+)
+
+// loginRejectedJS checks whether Skool showed a wrong email or password message
+const loginRejectedJS = `document.body !== null && (document.body.textContent.includes('Incorrect password') || document.body.textContent.includes('No account found for this email.'))`
+
+// errPollTimeout is returned by pollWithBackoff when the budget runs out
+var errPollTimeout = errors.New("timed out")
+
+// errLoginNavigation marks a login page that failed to load, which is worth
+// retrying
+var errLoginNavigation = errors.New("page failed to load")
+
+// errInvalidCredentials is returned when Skool rejects the email or password
+var errInvalidCredentials = errors.New("login failed: invalid credentials, Skool rejected the email or password")
+
+// selectorTimeoutError is returned when a login page element never appears,
+// as opposed to the credentials being rejected
+type selectorTimeoutError struct {
+	what   string
+	budget time.Duration
+}
+
+func (e *selectorTimeoutError) Error() string {
+	return fmt.Sprintf("the %s didn't appear within %s, the page may be slow (raise -login-wait) or Skool's login page may have changed", e.what, e.budget)
+}
+
+// pollWithBackoff calls check until it reports done, doubling the delay
+// between calls from initial up to maxDelay. It returns errPollTimeout once budget
+// has passed, or the first error from check or ctx.
+func pollWithBackoff(ctx context.Context, budget, initial, maxDelay time.Duration, check func() (bool, error)) error {
+	deadline := time.Now().Add(budget)
+	delay := initial
+	for {
+		done, err := check()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return errPollTimeout
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(min(delay, remaining)):
+		}
+		delay = min(2*delay, maxDelay)
+	}
+}
+
+// visibleXPathJS returns a script that checks whether the first element
+// matching xpath is rendered
+func visibleXPathJS(xpath string) string {
+	return fmt.Sprintf(`(() => {
+		const el = document.evaluate(%q, document, null, XPathResult.FIRST_ORDERED_NODE_TYPE, null).singleNodeValue;
+		return el !== null && el.getClientRects().length > 0;
+	})()`, xpath)
+}
+
+// waitForLoginElement polls until one of el's strategies finds a visible
+// element and returns its XPath, or a selectorTimeoutError if none does
+// within budget
+func waitForLoginElement(ctx context.Context, el loginElement, budget time.Duration) (string, error) {
+	var found selectorStrategy
+	err := pollWithBackoff(ctx, budget, loginPollInitialDelay, loginPollMaxDelay, func() (bool, error) {
+		strategy, ok, err := firstVisibleStrategy(el, func(xpath string) (bool, error) {
+			var visible bool
+			err := chromedp.Run(ctx, chromedp.Evaluate(visibleXPathJS(xpath), &visible))
+			return visible, err
+		})
+		found = strategy
+		return ok, err
+	})
+	if errors.Is(err, errPollTimeout) {
+		return "", &selectorTimeoutError{what: el.what, budget: budget}
+	}
+	if err != nil {
+		return "", err
+	}
+	console.Debugf("Found the %s with the %q selector", el.what, found.name)
+	return found.xpath, nil
+}
+
+// waitForLoginResult polls until the auth_token cookie is set or Skool
+// rejects the credentials. If neither happens within budget it falls back to
+// checking the page with loginSuccessJS.
+func waitForLoginResult(ctx context.Context, budget time.Duration) (bool, error) {
+	err := pollWithBackoff(ctx, budget, loginPollInitialDelay, loginPollMaxDelay, func() (bool, error) {
+		var rejected bool
+		if err := chromedp.Run(ctx, chromedp.Evaluate(loginRejectedJS, &rejected)); err != nil {
+			return false, err
+		}
+		if rejected {
+			return false, errInvalidCredentials
+		}
+		cookies, err := browserCookies(ctx)
+		if err != nil {
+			return false, err
+		}
+		return hasAuthCookie(cookies), nil
+	})
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, errPollTimeout) {
+		return false, err
+	}
+
+	var loginSuccess bool
+	if err := chromedp.Run(ctx, chromedp.Evaluate(loginSuccessJS, &loginSuccess)); err != nil {
+		return false, fmt.Errorf("login process failed: %v", err)
+	}
+	return loginSuccess, nil
+}
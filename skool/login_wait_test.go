@@ -0,0 +1,67 @@
+package skool
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPollWithBackoff(t *testing.T) {
+	t.Run("done after a few checks", func(t *testing.T) {
+		calls := 0
+		err := pollWithBackoff(context.Background(), time.Second, time.Millisecond, 4*time.Millisecond, func() (bool, error) {
+			calls++
+			return calls == 3, nil
+		})
+		if err != nil || calls != 3 {
+			t.Errorf("pollWithBackoff() = %v after %d calls, want nil after 3", err, calls)
+		}
+	})
+
+	t.Run("budget runs out", func(t *testing.T) {
+		start := time.Now()
+		err := pollWithBackoff(context.Background(), 20*time.Millisecond, time.Millisecond, 4*time.Millisecond, func() (bool, error) {
+			return false, nil
+		})
+		if !errors.Is(err, errPollTimeout) {
+			t.Errorf("Expected errPollTimeout, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("Polling overran its budget: %s", elapsed)
+		}
+	})
+
+	t.Run("check error stops polling", func(t *testing.T) {
+		calls := 0
+		err := pollWithBackoff(context.Background(), time.Second, time.Millisecond, time.Millisecond, func() (bool, error) {
+			calls++
+			return false, errInvalidCredentials
+		})
+		if !errors.Is(err, errInvalidCredentials) || calls != 1 {
+			t.Errorf("pollWithBackoff() = %v after %d calls, want errInvalidCredentials after 1", err, calls)
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := pollWithBackoff(ctx, time.Second, 100*time.Millisecond, 100*time.Millisecond, func() (bool, error) {
+			return false, nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
+
+func TestSelectorTimeoutError(t *testing.T) {
+	err := error(&selectorTimeoutError{what: "email field", budget: 15 * time.Second})
+	if !strings.Contains(err.Error(), "email field didn't appear within 15s") || !strings.Contains(err.Error(), "-login-wait") {
+		t.Errorf("Unexpected message: %v", err)
+	}
+	if errors.Is(err, errInvalidCredentials) {
+		t.Error("A missing element must not look like rejected credentials")
+	}
+}
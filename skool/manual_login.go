@@ -0,0 +1,85 @@
+package skool
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// manualLoginPollInterval is how often the browser is checked for a session
+// while waiting for the user to log in
+const manualLoginPollInterval = 2 * time.Second
+
+// hasAuthCookie reports whether cookies include Skool's auth_token
+func hasAuthCookie(cookies []JSONCookie) bool {
+	for _, c := range cookies {
+		if c.Name == skoolAuthCookie && isSkoolHost(c.Host) {
+			return true
+		}
+	}
+	return false
+}
+
+// scrapeWithManualLogin opens a visible browser on skool.com and waits for the
+// user to log in by hand, which sidesteps captchas and two-factor prompts
+func scrapeWithManualLogin(parent context.Context, config Config) (*scrapeResult, error) {
+	// The login wait gets its own -timeout on top of the time for scraping
+	browserConfig := config
+	browserConfig.Headless = false
+	ctx, cancel, err := setupBrowser(parent, browserConfig, 2*config.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	if profileHasSession(ctx, config) {
+		return navigateAndScrape(ctx, config)
+	}
+
+	if err := chromedp.Run(ctx, chromedp.Navigate(skoolLoginURL)); err != nil {
+		return nil, fmt.Errorf("failed to navigate to Skool: %v", err)
+	}
+
+	console.Authf("Please log in to Skool in the browser window (waiting up to %s)...", config.Timeout)
+	stopTimer := timings.Start("authentication")
+	err = waitForManualLogin(ctx, config.Timeout)
+	stopTimer()
+	if err != nil {
+		return nil, err
+	}
+
+	console.Success("Login detected!")
+
+	if config.SaveCookies != "" {
+		if count, err := saveBrowserCookies(ctx, config.SaveCookies); err != nil {
+			console.Warningf("Failed to save cookies: %v", err)
+		} else {
+			console.Authf("Saved %d cookie(s) to %s, reuse them with -cookies=%s", count, config.SaveCookies, config.SaveCookies)
+		}
+	}
+	return navigateAndScrape(ctx, config)
+}
+
+// waitForManualLogin polls the browser until the auth_token cookie appears
+func waitForManualLogin(ctx context.Context, timeout time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		cookies, err := browserCookies(ctx)
+		if err != nil {
+			return err
+		}
+		if hasAuthCookie(cookies) {
+			return nil
+		}
+
+		select {
+		case <-waitCtx.Done():
+			return fmt.Errorf("timed out after %s waiting for manual login, rerun -manual-login and log in within the time limit", timeout)
+		case <-time.After(manualLoginPollInterval):
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package skool
+
+import "testing"
+
+func TestHasAuthCookie(t *testing.T) {
+	tests := []struct {
+		name     string
+		cookies  []JSONCookie
+		expected bool
+	}{
+		{
+			name:     "No cookies",
+			cookies:  nil,
+			expected: false,
+		},
+		{
+			name:     "Auth token on skool.com",
+			cookies:  []JSONCookie{{Host: ".skool.com", Name: "auth_token", Value: "abc"}},
+			expected: true,
+		},
+		{
+			name:     "Auth token on www subdomain",
+			cookies:  []JSONCookie{{Host: "www.skool.com", Name: "auth_token", Value: "abc"}},
+			expected: true,
+		},
+		{
+			name:     "Only anonymous cookies",
+			cookies:  []JSONCookie{{Host: ".skool.com", Name: "client_id", Value: "abc"}},
+			expected: false,
+		},
+		{
+			name:     "Auth token for another site",
+			cookies:  []JSONCookie{{Host: "example.com", Name: "auth_token", Value: "abc"}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasAuthCookie(tt.cookies); got != tt.expected {
+				t.Errorf("hasAuthCookie() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package skool
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// runContext returns the context the whole run happens in, which expires
+// after -max-runtime, or never when it's 0
+func runContext(maxRuntime time.Duration) (context.Context, context.CancelFunc) {
+	if maxRuntime <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), maxRuntime)
+}
+
+// skipAfterDeadline records the videos that weren't downloaded before
+// -max-runtime expired as skipped, lists them and returns the error ending
+// the run
+func skipAfterDeadline(report *downloadReport, remaining []VideoEntry, total int, maxRuntime time.Duration) error {
+	report.Skip(remaining)
+	console.Warningf("-max-runtime of %s reached, %d video(s) not downloaded:", maxRuntime, len(remaining))
+	for _, video := range remaining {
+		if video.Title != "" {
+			console.Warningf("  %s (%s)", video.Title, video.URL)
+		} else {
+			console.Warningf("  %s", video.URL)
+		}
+	}
+	return fmt.Errorf("-max-runtime of %s reached (%d of %d videos not downloaded)", maxRuntime, len(remaining), total)
+}
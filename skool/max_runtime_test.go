@@ -0,0 +1,67 @@
+package skool
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunContext(t *testing.T) {
+	ctx, cancel := runContext(0)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("Expected no deadline without -max-runtime")
+	}
+
+	ctx, cancel = runContext(time.Hour)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > time.Hour {
+		t.Errorf("Expected a deadline within an hour, got %v (set: %v)", deadline, ok)
+	}
+}
+
+func TestSkipAfterDeadline(t *testing.T) {
+	report := &downloadReport{}
+	report.Add(VideoEntry{URL: "https://www.loom.com/share/a"}, "a.mp4", "", nil)
+	remaining := []VideoEntry{
+		{URL: "https://www.loom.com/share/b", Title: "Second"},
+		{URL: "https://www.loom.com/share/c"},
+	}
+
+	err := skipAfterDeadline(report, remaining, 3, time.Minute)
+	if err == nil || !strings.Contains(err.Error(), "2 of 3") {
+		t.Errorf("Expected an error counting 2 of 3 videos, got %v", err)
+	}
+	if succeeded, failed, skipped := report.Counts(); succeeded != 1 || failed != 0 || skipped != 2 {
+		t.Errorf("Counts() = %d, %d, %d, want 1, 0, 2", succeeded, failed, skipped)
+	}
+}
+
+func TestDownloadWithYtDlp_KilledAtDeadline(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake yt-dlp is a shell script")
+	}
+
+	fakeYtDlp := filepath.Join(t.TempDir(), "yt-dlp")
+	if err := os.WriteFile(fakeYtDlp, []byte("#!/bin/sh\nexec sleep 30\n"), 0755); err != nil {
+		t.Fatalf("Failed to create fake yt-dlp: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	config := Config{YtDlpPath: fakeYtDlp, OutputDir: t.TempDir()}
+	_, err := downloadWithYtDlp(ctx, VideoEntry{URL: "https://www.loom.com/share/abc"}, config, nil)
+	if err == nil {
+		t.Fatal("Expected an error when the deadline kills yt-dlp")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected yt-dlp to be killed at the deadline, took %v", elapsed)
+	}
+}
@@ -0,0 +1,51 @@
+package skool
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Backoff between readiness checks, short since most pages are ready quickly
+const (
+	pageReadyInitialDelay = 100 * time.Millisecond
+	pageReadyMaxDelay     = 500 * time.Millisecond
+)
+
+// pageReadyJS checks that the page finished loading and Next.js rendered its
+// data, which every usable Skool page has
+const pageReadyJS = `document.readyState === "complete" && document.getElementById("__NEXT_DATA__") !== null`
+
+// loginDoneJS checks that the page finished loading somewhere other than the
+// login form, which is where Skool sends a signed-in session
+const loginDoneJS = `document.readyState === "complete" && !window.location.pathname.startsWith("/login")`
+
+// waitReady polls script until it returns true, for at most budget. A page
+// that never signals is still used once budget has passed, as with the fixed
+// waits this replaces, so only a cancelled context is an error.
+func waitReady(script string, budget time.Duration) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		start := time.Now()
+		budget := human.Jitter(budget)
+		err := pollWithBackoff(ctx, budget, pageReadyInitialDelay, pageReadyMaxDelay, func() (bool, error) {
+			var ready bool
+			if err := chromedp.Evaluate(script, &ready).Do(ctx); err != nil {
+				// The page may still be navigating, which destroys the context the
+				// script ran in
+				return false, ctx.Err()
+			}
+			return ready, nil
+		})
+		switch {
+		case errors.Is(err, errPollTimeout):
+			console.Debugf("Page didn't signal ready within %s, continuing anyway", budget)
+			return nil
+		case err != nil:
+			return err
+		}
+		console.Debugf("Page ready after %s", time.Since(start).Round(time.Millisecond))
+		return nil
+	})
+}
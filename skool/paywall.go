@@ -0,0 +1,60 @@
+package skool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/chromedp/chromedp"
+)
+
+// errNoAccess is returned when the session is valid but the account isn't a
+// member of the classroom, or its membership tier doesn't include the course
+var errNoAccess = errors.New("your account doesn't have access to this classroom")
+
+// paywallMarkers are lowercase texts Skool shows on join, upgrade and locked
+// course pages
+var paywallMarkers = []string{
+	"upgrade to unlock",
+	"unlock this course",
+	"this course is locked",
+	"request to join",
+	"join group",
+	"membership required",
+}
+
+// noAccessRegex matches the access flag Skool sets on locked courses in __NEXT_DATA__
+var noAccessRegex = regexp.MustCompile(`"hasAccess"\s*:\s*false`)
+
+// detectPaywall returns the marker that shows html is a join or upgrade page
+// instead of the classroom, or "" if none was found
+func detectPaywall(html string) string {
+	if marker := noAccessRegex.FindString(html); marker != "" {
+		return marker
+	}
+	lower := strings.ToLower(html)
+	for _, marker := range paywallMarkers {
+		if strings.Contains(lower, marker) {
+			return marker
+		}
+	}
+	return ""
+}
+
+// checkPaywall returns an errNoAccess error if the current page is a join or
+// upgrade page
+func checkPaywall(ctx context.Context) error {
+	var html, currentURL string
+	if err := chromedp.Run(ctx, chromedp.OuterHTML("html", &html), chromedp.Location(&currentURL)); err != nil {
+		console.Debugf("Failed to read the page to check for a paywall: %v", err)
+		return nil
+	}
+	marker := detectPaywall(html)
+	if marker == "" {
+		return nil
+	}
+	console.Debugf("Found paywall marker %q on %s", marker, currentURL)
+	return fmt.Errorf("%w (landed on %s). Join the community or upgrade your membership with this account in a browser, then try again", errNoAccess, currentURL)
+}
@@ -0,0 +1,25 @@
+package skool
+
+import "testing"
+
+func TestDetectPaywall(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"locked course flag", `{"course":{"id":"c1","hasAccess": false}}`, `"hasAccess": false`},
+		{"upgrade text", `<p>Upgrade to Unlock this content</p>`, "upgrade to unlock"},
+		{"join page", `<button>JOIN GROUP</button>`, "join group"},
+		{"classroom", `{"course":{"id":"c1","hasAccess":true}}<p>Welcome</p>`, ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectPaywall(tt.html); got != tt.want {
+				t.Errorf("detectPaywall() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
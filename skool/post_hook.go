@@ -0,0 +1,29 @@
+package skool
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// runPostHook runs the -post-hook command for a downloaded video. The file
+// path and lesson title are passed as arguments and, along with the video URL
+// and module, as SKOOL_VIDEO_* environment variables.
+func runPostHook(hook, file string, video VideoEntry) error {
+	cmd := exec.Command(hook, file, video.Title)
+	cmd.Env = append(os.Environ(),
+		"SKOOL_VIDEO_FILE="+file,
+		"SKOOL_VIDEO_TITLE="+video.Title,
+		"SKOOL_VIDEO_MODULE="+video.Module,
+		"SKOOL_VIDEO_URL="+video.URL,
+	)
+	// Keep the hook's output with ours, which is stderr in -json mode
+	cmd.Stdout = console.out
+	cmd.Stderr = console.out
+
+	console.Debug("Running post-hook:", hook, file)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("post-hook %s failed for %s: %v", hook, file, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package skool
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRunPostHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake hook is a shell script")
+	}
+
+	dir := t.TempDir()
+	hook := filepath.Join(dir, "hook.sh")
+	script := `#!/bin/sh
+echo "args: $1 | $2"
+echo "env: $SKOOL_VIDEO_FILE | $SKOOL_VIDEO_TITLE | $SKOOL_VIDEO_MODULE | $SKOOL_VIDEO_URL"
+`
+	if err := os.WriteFile(hook, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	original := console
+	console = newLogger(levelNormal, &out)
+	defer func() { console = original }()
+
+	video := VideoEntry{URL: "https://www.loom.com/share/abc123", Title: "Welcome", Module: "Getting Started"}
+	if err := runPostHook(hook, "downloads/Welcome.mp4", video); err != nil {
+		t.Fatalf("runPostHook() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"args: downloads/Welcome.mp4 | Welcome",
+		"env: downloads/Welcome.mp4 | Welcome | Getting Started | https://www.loom.com/share/abc123",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected hook output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestRunPostHook_Failure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake hook is a shell script")
+	}
+
+	hook := filepath.Join(t.TempDir(), "hook.sh")
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\nexit 3\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	err := runPostHook(hook, "video.mp4", VideoEntry{})
+	if err == nil || !strings.Contains(err.Error(), "exit status 3") {
+		t.Errorf("Expected the hook's exit status in the error, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package skool
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// stageTiming is the elapsed time of a single stage of a run
+type stageTiming struct {
+	Name     string
+	Duration time.Duration
+}
+
+// stageTimer accumulates per-stage timings for the -profile breakdown.
+// A disabled timer records nothing, so call sites don't need to check the flag.
+type stageTimer struct {
+	enabled bool
+	started time.Time
+	stages  []stageTiming
+	now     func() time.Time
+}
+
+// timings is the stage timer used throughout the application
+var timings = newStageTimer(false)
+
+func newStageTimer(enabled bool) *stageTimer {
+	return &stageTimer{enabled: enabled, started: time.Now(), now: time.Now}
+}
+
+// Start begins timing a stage and returns a function that records it when called
+func (t *stageTimer) Start(name string) func() {
+	if !t.enabled {
+		return func() {}
+	}
+	start := t.now()
+	return func() {
+		t.Record(name, t.now().Sub(start))
+	}
+}
+
+// Record adds an already measured stage, merging it into an existing stage of the same name
+func (t *stageTimer) Record(name string, d time.Duration) {
+	if !t.enabled {
+		return
+	}
+	for i := range t.stages {
+		if t.stages[i].Name == name {
+			t.stages[i].Duration += d
+			return
+		}
+	}
+	t.stages = append(t.stages, stageTiming{Name: name, Duration: d})
+}
+
+// Stages returns the recorded stages in the order they were first seen
+func (t *stageTimer) Stages() []stageTiming {
+	return t.stages
+}
+
+// Total returns the wall-clock time since the timer was created
+func (t *stageTimer) Total() time.Duration {
+	return t.now().Sub(t.started)
+}
+
+// Report writes the timing breakdown as an aligned table
+func (t *stageTimer) Report(w io.Writer) error {
+	if !t.enabled {
+		return nil
+	}
+
+	width := len("total")
+	for _, s := range t.stages {
+		if len(s.Name) > width {
+			width = len(s.Name)
+		}
+	}
+
+	if _, err := fmt.Fprintln(w, "Timing breakdown:"); err != nil {
+		return err
+	}
+	for _, s := range t.stages {
+		if _, err := fmt.Fprintf(w, "  %-*s  %s\n", width, s.Name, s.Duration.Round(time.Millisecond)); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "  %-*s  %s\n", width, "total", t.Total().Round(time.Millisecond))
+	return err
+}
@@ -0,0 +1,75 @@
+package skool
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock returns a now function that advances by step on every call
+func fakeClock(start time.Time, step time.Duration) func() time.Time {
+	current := start
+	return func() time.Time {
+		now := current
+		current = current.Add(step)
+		return now
+	}
+}
+
+func TestStageTimer_AccumulatesStages(t *testing.T) {
+	timer := newStageTimer(true)
+	timer.now = fakeClock(time.Unix(0, 0), time.Second)
+
+	stop := timer.Start("browser launch")
+	stop()
+	timer.Record("extraction", 2*time.Second)
+	timer.Record("extraction", 3*time.Second)
+
+	stages := timer.Stages()
+	if len(stages) != 2 {
+		t.Fatalf("Expected 2 stages, got %d", len(stages))
+	}
+	if stages[0].Name != "browser launch" || stages[0].Duration != time.Second {
+		t.Errorf("Unexpected first stage: %+v", stages[0])
+	}
+	if stages[1].Name != "extraction" || stages[1].Duration != 5*time.Second {
+		t.Errorf("Expected repeated stage to accumulate to 5s, got %+v", stages[1])
+	}
+}
+
+func TestStageTimer_Disabled(t *testing.T) {
+	timer := newStageTimer(false)
+	timer.Start("browser launch")()
+	timer.Record("extraction", time.Second)
+
+	if len(timer.Stages()) != 0 {
+		t.Errorf("Expected disabled timer to record nothing, got %v", timer.Stages())
+	}
+
+	var buf bytes.Buffer
+	if err := timer.Report(&buf); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no report from disabled timer, got %q", buf.String())
+	}
+}
+
+func TestStageTimer_Report(t *testing.T) {
+	timer := newStageTimer(true)
+	timer.Record("classroom navigation", 1500*time.Millisecond)
+
+	var buf bytes.Buffer
+	if err := timer.Report(&buf); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "classroom navigation  1.5s") {
+		t.Errorf("Expected stage line in report, got %q", out)
+	}
+	if !strings.Contains(out, "total") {
+		t.Errorf("Expected total line in report, got %q", out)
+	}
+}
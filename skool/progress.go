@@ -0,0 +1,95 @@
+package skool
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// progressLinePrefix marks the lines written by ytDlpProgressTemplate so they
+// can be told apart from yt-dlp's other output
+const progressLinePrefix = "skooldl-progress:"
+
+// ytDlpProgressTemplate makes yt-dlp print one machine-readable line per progress update
+const ytDlpProgressTemplate = "download:" + progressLinePrefix + "%(progress._percent_str)s|%(info.title)s"
+
+// progressBarWidth is the number of cells in the rendered bar
+const progressBarWidth = 30
+
+// parseProgressLine extracts the percentage and title from a line printed with
+// ytDlpProgressTemplate, reporting false for any other line
+func parseProgressLine(line string) (float64, string, bool) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(line), progressLinePrefix)
+	if !ok {
+		return 0, "", false
+	}
+
+	percentStr, title, _ := strings.Cut(rest, "|")
+	percentStr = strings.TrimSuffix(strings.TrimSpace(percentStr), "%")
+	percent, err := strconv.ParseFloat(percentStr, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return percent, strings.TrimSpace(title), true
+}
+
+// progressBar renders a single-line bar aggregated across all videos of a run
+type progressBar struct {
+	out   io.Writer
+	index int // 1-based position of the current video
+	total int
+}
+
+func newProgressBar(out io.Writer, index, total int) *progressBar {
+	return &progressBar{out: out, index: index, total: total}
+}
+
+// Render redraws the bar for the current video's progress. The bar shows the
+// overall progress of the run, the text the current video's.
+func (p *progressBar) Render(percent float64, title string) {
+	overall := (float64(p.index-1) + percent/100) / float64(p.total)
+	filled := int(overall * progressBarWidth)
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
+	line := fmt.Sprintf("[%d/%d] [%s] %3.0f%%", p.index, p.total, bar, percent)
+	if title != "" {
+		line += " — " + title
+	}
+	// Clear the rest of the terminal line so a shorter title doesn't leave leftovers
+	_, _ = fmt.Fprintf(p.out, "\r%s\033[K", line)
+}
+
+// Done ends the bar's line
+func (p *progressBar) Done() {
+	_, _ = fmt.Fprintln(p.out)
+}
+
+// copyProgress reads yt-dlp's stdout, rendering progress lines on the bar and
+// passing every other line through unchanged
+func copyProgress(r io.Reader, out io.Writer, bar *progressBar) error {
+	scanner := bufio.NewScanner(r)
+	rendered := false
+	for scanner.Scan() {
+		line := scanner.Text()
+		if percent, title, ok := parseProgressLine(line); ok {
+			bar.Render(percent, title)
+			rendered = true
+			continue
+		}
+
+		if rendered {
+			bar.Done()
+			rendered = false
+		}
+		_, _ = fmt.Fprintln(out, line)
+	}
+	if rendered {
+		bar.Done()
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,110 @@
+package skool
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseProgressLine(t *testing.T) {
+	tests := []struct {
+		name        string
+		line        string
+		wantPercent float64
+		wantTitle   string
+		wantOK      bool
+	}{
+		{
+			name:        "Padded percentage",
+			line:        "skooldl-progress:  45.3%|Lesson 1 - Intro",
+			wantPercent: 45.3,
+			wantTitle:   "Lesson 1 - Intro",
+			wantOK:      true,
+		},
+		{
+			name:        "Complete",
+			line:        "skooldl-progress:100.0%|Wrap-up",
+			wantPercent: 100,
+			wantTitle:   "Wrap-up",
+			wantOK:      true,
+		},
+		{
+			name:        "Title with separator",
+			line:        "skooldl-progress: 3.0%|Q|A session",
+			wantPercent: 3,
+			wantTitle:   "Q|A session",
+			wantOK:      true,
+		},
+		{
+			name:   "Unknown percentage",
+			line:   "skooldl-progress:   N/A|Live stream",
+			wantOK: false,
+		},
+		{
+			name:   "Regular yt-dlp output",
+			line:   "[loom] Extracting URL: https://www.loom.com/share/abc123",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			percent, title, ok := parseProgressLine(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("parseProgressLine() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if percent != tt.wantPercent {
+				t.Errorf("percent = %v, want %v", percent, tt.wantPercent)
+			}
+			if title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", title, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestProgressBar_Render(t *testing.T) {
+	var buf bytes.Buffer
+	bar := newProgressBar(&buf, 3, 20)
+	bar.Render(45, "Lesson Title")
+
+	got := buf.String()
+	if !strings.HasPrefix(got, "\r[3/20] [") {
+		t.Errorf("Render() should start with the position, got %q", got)
+	}
+	if !strings.Contains(got, " 45% — Lesson Title") {
+		t.Errorf("Render() should contain the percentage and title, got %q", got)
+	}
+
+	// 2.45 of 20 videos done fills 3 of 30 cells
+	if !strings.Contains(got, "[==="+strings.Repeat(" ", 27)+"]") {
+		t.Errorf("Render() bar should reflect overall progress, got %q", got)
+	}
+}
+
+func TestCopyProgress(t *testing.T) {
+	input := strings.Join([]string{
+		"[loom] Extracting URL: https://www.loom.com/share/abc123",
+		"skooldl-progress:  10.0%|Intro",
+		"skooldl-progress: 100.0%|Intro",
+		"[Merger] Merging formats",
+	}, "\n")
+
+	var out, barOut bytes.Buffer
+	if err := copyProgress(strings.NewReader(input), &out, newProgressBar(&barOut, 1, 1)); err != nil {
+		t.Fatalf("copyProgress() error: %v", err)
+	}
+
+	if want := "[loom] Extracting URL: https://www.loom.com/share/abc123\n[Merger] Merging formats\n"; out.String() != want {
+		t.Errorf("passthrough output = %q, want %q", out.String(), want)
+	}
+	if strings.Count(barOut.String(), "\r") != 2 {
+		t.Errorf("expected 2 bar renders, got %q", barOut.String())
+	}
+	if strings.Contains(barOut.String(), "skooldl-progress") {
+		t.Errorf("progress lines should not be passed through, got %q", barOut.String())
+	}
+}
@@ -0,0 +1,80 @@
+package skool
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// proxySchemes are the proxy URL schemes understood by both Chromium and yt-dlp
+var proxySchemes = []string{"http", "https", "socks5"}
+
+// validateProxy checks that raw is a proxy URL with a supported scheme and a host
+func validateProxy(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL %q: %v", raw, err)
+	}
+
+	supported := false
+	for _, scheme := range proxySchemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return fmt.Errorf("unsupported proxy scheme in %q (use %s)", raw, strings.Join(proxySchemes, ", "))
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("proxy URL %q has no host", raw)
+	}
+	return nil
+}
+
+// splitProxies splits a comma separated -proxy value into its proxy URLs
+func splitProxies(raw string) []string {
+	var proxies []string
+	for _, proxy := range strings.Split(raw, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+// firstProxy returns the first proxy of a -proxy value, which the browser
+// scrapes through, or "" if there is none
+func firstProxy(raw string) string {
+	if proxies := splitProxies(raw); len(proxies) > 0 {
+		return proxies[0]
+	}
+	return ""
+}
+
+// proxyRotator hands out proxies round-robin, so downloads are spread over
+// all of them
+type proxyRotator struct {
+	mu      sync.Mutex
+	proxies []string
+	next    int
+}
+
+// newProxyRotator returns a rotator over proxies, starting with the first
+func newProxyRotator(proxies []string) *proxyRotator {
+	return &proxyRotator{proxies: proxies}
+}
+
+// Next returns the proxy for the next download, or "" if there are none
+func (r *proxyRotator) Next() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.proxies) == 0 {
+		return ""
+	}
+	proxy := r.proxies[r.next]
+	r.next = (r.next + 1) % len(r.proxies)
+	return proxy
+}
@@ -0,0 +1,167 @@
+package skool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultReportName is the report written to the output directory when -report isn't set
+const defaultReportName = "report.txt"
+
+// Final status of a video in the download report
+const (
+	statusSucceeded = "succeeded"
+	statusFailed    = "failed"
+	statusSkipped   = "skipped"
+	statusTooLarge  = "too-large" // skipped by yt-dlp for -max-filesize
+)
+
+// reportEntry is the outcome of a single video
+type reportEntry struct {
+	URL      string        `json:"url"`
+	Title    string        `json:"title,omitempty"`
+	Platform videoPlatform `json:"platform,omitempty"`
+	Status   string        `json:"status"`
+	File     string        `json:"file,omitempty"`
+	SHA256   string        `json:"sha256,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// downloadReport collects the outcome of every video in a run
+type downloadReport struct {
+	Entries []reportEntry `json:"videos"`
+	// Extraction says how the videos of each scraped URL were found
+	Extraction []extractionSummary `json:"extraction,omitempty"`
+}
+
+// Add records the outcome of a download, marking it failed when err is set,
+// or too large when err is errTooLarge. checksum is the file's SHA-256, or
+// empty when -checksum is off.
+func (r *downloadReport) Add(video VideoEntry, file, checksum string, err error) {
+	entry := reportEntry{URL: video.URL, Title: video.Title, Platform: video.Platform, Status: statusSucceeded, File: file, SHA256: checksum}
+	switch {
+	case errors.Is(err, errTooLarge):
+		entry.Status = statusTooLarge
+		entry.Error = err.Error()
+	case err != nil:
+		entry.Status = statusFailed
+		entry.Error = err.Error()
+	}
+	r.Entries = append(r.Entries, entry)
+}
+
+// Skip records videos that were never attempted
+func (r *downloadReport) Skip(videos []VideoEntry) {
+	for _, v := range videos {
+		r.Entries = append(r.Entries, reportEntry{URL: v.URL, Title: v.Title, Platform: v.Platform, Status: statusSkipped})
+	}
+}
+
+// Counts returns the number of succeeded, failed and skipped videos, where
+// videos over -max-filesize count as skipped
+func (r *downloadReport) Counts() (succeeded, failed, skipped int) {
+	for _, e := range r.Entries {
+		switch e.Status {
+		case statusSucceeded:
+			succeeded++
+		case statusFailed:
+			failed++
+		case statusSkipped, statusTooLarge:
+			skipped++
+		}
+	}
+	return succeeded, failed, skipped
+}
+
+// Summary returns a one-line count of the outcomes, saying how many of the
+// skipped videos were over -max-filesize
+func (r *downloadReport) Summary() string {
+	succeeded, failed, skipped := r.Counts()
+	summary := fmt.Sprintf("%d succeeded, %d failed, %d skipped", succeeded, failed, skipped)
+
+	tooLarge := 0
+	for _, e := range r.Entries {
+		if e.Status == statusTooLarge {
+			tooLarge++
+		}
+	}
+	if tooLarge > 0 {
+		summary += fmt.Sprintf(" (%d over -max-filesize)", tooLarge)
+	}
+	return summary
+}
+
+// WriteFile writes the report to path, as JSON when it ends in .json and as
+// plain text otherwise
+func (r *downloadReport) WriteFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = r.WriteJSON(file)
+	} else {
+		err = r.WriteText(file)
+	}
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// WriteJSON writes the report as indented JSON
+func (r *downloadReport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteText writes one block per video followed by the summary line
+func (r *downloadReport) WriteText(w io.Writer) error {
+	for i, e := range r.Entries {
+		title := e.Title
+		if title == "" {
+			title = untitledLesson
+		}
+		if _, err := fmt.Fprintf(w, "[%d/%d] %s: %s\n  URL: %s\n", i+1, len(r.Entries), strings.ToUpper(e.Status), title, e.URL); err != nil {
+			return err
+		}
+		if e.Platform != "" {
+			if _, err := fmt.Fprintf(w, "  Platform: %s\n", e.Platform); err != nil {
+				return err
+			}
+		}
+		if e.File != "" {
+			if _, err := fmt.Fprintf(w, "  File: %s\n", e.File); err != nil {
+				return err
+			}
+		}
+		if e.SHA256 != "" {
+			if _, err := fmt.Fprintf(w, "  SHA-256: %s\n", e.SHA256); err != nil {
+				return err
+			}
+		}
+		if e.Error != "" {
+			if _, err := fmt.Fprintf(w, "  Error: %s\n", e.Error); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+	for _, e := range r.Extraction {
+		if _, err := fmt.Fprintf(w, "Extraction: %d video(s) from %s via %s\n", e.Videos, e.URL, e.Source); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "Summary: %s\n", r.Summary())
+	return err
+}
@@ -0,0 +1,117 @@
+package skool
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testReport() *downloadReport {
+	report := &downloadReport{}
+	report.Add(VideoEntry{URL: "https://www.loom.com/share/a", Title: "Intro", Platform: platformLoom}, "downloads/Intro.mp4", "abc123", nil)
+	report.Add(VideoEntry{URL: "https://www.loom.com/share/b"}, "", "", errors.New("yt-dlp failed: exit status 1"))
+	report.Skip([]VideoEntry{{URL: "https://www.loom.com/share/c", Title: "Outro"}})
+	report.Extraction = []extractionSummary{{URL: "https://www.skool.com/group/classroom/abc", Source: sourceNextData, Videos: 3}}
+	return report
+}
+
+func TestDownloadReport_Counts(t *testing.T) {
+	succeeded, failed, skipped := testReport().Counts()
+	if succeeded != 1 || failed != 1 || skipped != 1 {
+		t.Errorf("Counts() = %d, %d, %d, want 1, 1, 1", succeeded, failed, skipped)
+	}
+	if got := testReport().Summary(); got != "1 succeeded, 1 failed, 1 skipped" {
+		t.Errorf("Summary() = %q", got)
+	}
+}
+
+func TestDownloadReport_TooLarge(t *testing.T) {
+	report := testReport()
+	report.Add(VideoEntry{URL: "https://www.loom.com/share/d", Title: "Workshop"}, "", "", fmt.Errorf("%w 500M", errTooLarge))
+
+	if _, failed, skipped := report.Counts(); failed != 1 || skipped != 2 {
+		t.Errorf("Counts() failed, skipped = %d, %d, want 1, 2", failed, skipped)
+	}
+	if got := report.Summary(); got != "1 succeeded, 1 failed, 2 skipped (1 over -max-filesize)" {
+		t.Errorf("Summary() = %q", got)
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteText(&buf); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+	if want := "[4/4] TOO-LARGE: Workshop"; !strings.Contains(buf.String(), want) {
+		t.Errorf("Report is missing %q:\n%s", want, buf.String())
+	}
+}
+
+func TestDownloadReport_WriteText(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testReport().WriteText(&buf); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{
+		"[1/3] SUCCEEDED: Intro",
+		"Platform: loom",
+		"File: downloads/Intro.mp4",
+		"SHA-256: abc123",
+		"[2/3] FAILED: " + untitledLesson,
+		"Error: yt-dlp failed: exit status 1",
+		"[3/3] SKIPPED: Outro",
+		"Extraction: 3 video(s) from https://www.skool.com/group/classroom/abc via next-data",
+		"Summary: 1 succeeded, 1 failed, 1 skipped",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Report is missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDownloadReport_WriteFileJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := testReport().WriteFile(path); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded downloadReport
+	if err := json.Unmarshal(content, &decoded); err != nil {
+		t.Fatalf("Report is not valid JSON: %v\n%s", err, content)
+	}
+	if len(decoded.Entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(decoded.Entries))
+	}
+	if e := decoded.Entries[1]; e.Status != statusFailed || e.Error == "" {
+		t.Errorf("Unexpected failed entry: %+v", e)
+	}
+	if e := decoded.Entries[2]; e.Status != statusSkipped || e.Title != "Outro" {
+		t.Errorf("Unexpected skipped entry: %+v", e)
+	}
+	if len(decoded.Extraction) != 1 || decoded.Extraction[0].Source != sourceNextData {
+		t.Errorf("Unexpected extraction summary: %+v", decoded.Extraction)
+	}
+}
+
+func TestReadDownloadedPath(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "path.txt")
+	if err := os.WriteFile(path, []byte("downloads/a.mp4\ndownloads/b.mp4\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if got := readDownloadedPath(path); got != "downloads/b.mp4" {
+		t.Errorf("readDownloadedPath() = %q, want the last line", got)
+	}
+	if got := readDownloadedPath(filepath.Join(dir, "missing.txt")); got != "" {
+		t.Errorf("readDownloadedPath() on a missing file = %q, want empty", got)
+	}
+}
@@ -0,0 +1,94 @@
+package skool
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lessonTimestampLayouts are the string formats tried when parsing a lesson timestamp
+var lessonTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// lessonAdded returns when a lesson was added, from its createdAt field, or
+// the zero time if it can't be parsed. updatedAt changes on every edit, so a
+// lesson without a creation time is left undated rather than dated by it.
+func lessonAdded(courseObj map[string]interface{}) time.Time {
+	if t, ok := parseLessonTimestamp(courseObj["createdAt"]); ok {
+		return t
+	}
+	return time.Time{}
+}
+
+// parseLessonTimestamp parses a timestamp from __NEXT_DATA__, which may be a
+// date string or Unix time in seconds or milliseconds
+func parseLessonTimestamp(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case string:
+		s := strings.TrimSpace(v)
+		if s == "" {
+			return time.Time{}, false
+		}
+		for _, layout := range lessonTimestampLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, true
+			}
+		}
+		if n, err := strconv.ParseFloat(s, 64); err == nil {
+			return unixTimestamp(n)
+		}
+	case float64:
+		return unixTimestamp(v)
+	}
+	return time.Time{}, false
+}
+
+// unixTimestamp converts Unix time in seconds, or milliseconds for values
+// too large to be seconds, to a time
+func unixTimestamp(n float64) (time.Time, bool) {
+	if n <= 0 {
+		return time.Time{}, false
+	}
+	if n >= 1e12 {
+		return time.UnixMilli(int64(n)).UTC(), true
+	}
+	return time.Unix(int64(n), 0).UTC(), true
+}
+
+// parseSince parses the -since date, either YYYY-MM-DD or RFC 3339
+func parseSince(s string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid -since %q, use YYYY-MM-DD or an RFC 3339 timestamp", s)
+}
+
+// addedSince reports whether a video should be kept by -since. Videos without
+// a timestamp are kept since there's no way to tell when they were added.
+func addedSince(v VideoEntry, since time.Time) bool {
+	return v.Added.IsZero() || !v.Added.Before(since)
+}
+
+// filterSince returns the videos added at or after since, along with how many
+// were removed and how many were kept only because they have no timestamp
+func filterSince(videos []VideoEntry, since time.Time) (kept []VideoEntry, removed, undated int) {
+	for _, v := range videos {
+		if !addedSince(v, since) {
+			removed++
+			continue
+		}
+		if v.Added.IsZero() {
+			undated++
+		}
+		kept = append(kept, v)
+	}
+	return kept, removed, undated
+}
@@ -0,0 +1,79 @@
+package skool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLessonTimestamp(t *testing.T) {
+	want := time.Date(2024, 3, 5, 10, 20, 30, 0, time.UTC)
+	tests := []struct {
+		name  string
+		value interface{}
+		want  time.Time
+		ok    bool
+	}{
+		{"rfc3339", "2024-03-05T10:20:30Z", want, true},
+		{"rfc3339 with millis", "2024-03-05T10:20:30.000Z", want, true},
+		{"no zone", "2024-03-05T10:20:30", want, true},
+		{"space separated", "2024-03-05 10:20:30", want, true},
+		{"date only", "2024-03-05", time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC), true},
+		{"unix seconds", float64(want.Unix()), want, true},
+		{"unix millis", float64(want.UnixMilli()), want, true},
+		{"unix seconds as string", "1709634030", want, true},
+		{"empty", "", time.Time{}, false},
+		{"garbage", "last tuesday", time.Time{}, false},
+		{"missing", nil, time.Time{}, false},
+		{"zero", float64(0), time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseLessonTimestamp(tt.value)
+			if ok != tt.ok || !got.Equal(tt.want) {
+				t.Errorf("parseLessonTimestamp(%v) = %v, %v, want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestLessonAdded_IgnoresUpdatedAt(t *testing.T) {
+	courseObj := map[string]interface{}{"createdAt": "2024-03-05", "updatedAt": "2024-06-01"}
+	if got := lessonAdded(courseObj); !got.Equal(time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("lessonAdded() = %v, want createdAt", got)
+	}
+
+	courseObj = map[string]interface{}{"createdAt": "unknown", "updatedAt": "2024-06-01"}
+	if got := lessonAdded(courseObj); !got.IsZero() {
+		t.Errorf("lessonAdded() without a creation time = %v, want undated", got)
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	if _, err := parseSince("2024-03-05"); err != nil {
+		t.Errorf("Expected a date to parse: %v", err)
+	}
+	if _, err := parseSince("2024-03-05T10:00:00+02:00"); err != nil {
+		t.Errorf("Expected an RFC 3339 timestamp to parse: %v", err)
+	}
+	if _, err := parseSince("05/03/2024"); err == nil {
+		t.Error("Expected an error for an unsupported date format")
+	}
+}
+
+func TestFilterSince(t *testing.T) {
+	since := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	videos := []VideoEntry{
+		{URL: "old", Added: since.AddDate(0, 0, -1)},
+		{URL: "exact", Added: since},
+		{URL: "new", Added: since.AddDate(0, 1, 0)},
+		{URL: "undated"},
+	}
+
+	kept, removed, undated := filterSince(videos, since)
+	if removed != 1 || undated != 1 {
+		t.Errorf("filterSince() removed %d and kept %d undated, want 1 and 1", removed, undated)
+	}
+	if got := videoURLs(kept); len(got) != 3 || got[0] != "exact" || got[1] != "new" || got[2] != "undated" {
+		t.Errorf("filterSince() kept %v", got)
+	}
+}
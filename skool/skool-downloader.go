@@ -0,0 +1,2679 @@
+// Package skool scrapes skool.com classrooms for their videos and downloads
+// them with yt-dlp. ExtractVideos gives the videos of a classroom without
+// downloading; Main runs the skool-downloader command line.
+package skool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/chromedp"
+)
+
+const (
+	defaultWaitTime  = 2
+	defaultOutputDir = "downloads"
+	defaultHeadless  = true
+	defaultYtDlpPath = "yt-dlp"
+	browserTimeout   = 180 * time.Second
+	initialWaitTime  = 3 * time.Second // longest wait for the first page to be ready
+	loginWaitTime    = 3 * time.Second // longest wait for the page after a login step
+	skoolBaseURL     = "https://www.skool.com/"
+	skoolLoginURL    = "https://www.skool.com/login"
+	stdinCookiesPath = "-"
+
+	// defaultUserAgent is a current desktop Chrome, used by the browser and yt-dlp
+	defaultUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/141.0.0.0 Safari/537.36"
+
+	defaultNextDataRetries = 3
+	nextDataRetryDelay     = 2 * time.Second
+)
+
+// loginSuccessJS checks whether the login form was accepted
+const loginSuccessJS = `!window.location.href.includes('/login') && !document.body.textContent.includes('Incorrect password') && !document.body.textContent.includes('No account found for this email.')`
+
+// ANSI color codes
+const (
+	colorReset   = "\033[0m"
+	colorRed     = "\033[31m"
+	colorGreen   = "\033[32m"
+	colorYellow  = "\033[33m"
+	colorBlue    = "\033[34m"
+	colorMagenta = "\033[35m"
+	colorCyan    = "\033[36m"
+	colorGray    = "\033[90m"
+)
+
+// Colored log prefixes
+const (
+	prefixInfo     = colorBlue + "[INFO]" + colorReset
+	prefixSuccess  = colorGreen + "[SUCCESS]" + colorReset
+	prefixError    = colorRed + "[ERROR]" + colorReset
+	prefixWarning  = colorYellow + "[WARNING]" + colorReset
+	prefixAuth     = colorMagenta + "[AUTH]" + colorReset
+	prefixDownload = colorCyan + "[DOWNLOAD]" + colorReset
+	prefixDebug    = colorGray + "[DEBUG]" + colorReset
+)
+
+// JSONCookie represents a cookie in the JSON format
+type JSONCookie struct {
+	Host       string `json:"host"`
+	Name       string `json:"name"`
+	Value      string `json:"value"`
+	Path       string `json:"path"`
+	Expiry     int64  `json:"expiry"`
+	IsSecure   int    `json:"isSecure"`
+	IsHttpOnly int    `json:"isHttpOnly"`
+	SameSite   int    `json:"sameSite"`
+}
+
+// Config holds application configuration
+type Config struct {
+	SkoolURL           string
+	CookiesFile        string
+	Email              string
+	Password           string
+	OutputDir          string
+	Timeout            time.Duration
+	NextDataRetries    int
+	WaitTime           int
+	Headless           bool
+	BrowserPath        string
+	Engine             string
+	YtDlpPath          string
+	Quiet              bool
+	Verbose            bool
+	ExportCookies      string
+	SaveCookies        string
+	Profile            bool
+	ProfileFile        string
+	Deep               bool
+	Tabs               int
+	ForceIPv4          bool
+	EmbedMetadata      bool
+	YtDlpArgs          string
+	Proxy              string
+	UserAgent          string
+	RateLimit          string
+	Recode             string
+	SkipOnErrorCount   int
+	CookiesFromBrowser string
+	CookiesPassword    string
+	CookiesFormat      string
+	SameSiteZero       string
+	EncryptCookies     string
+	TOTPSecret         string
+	ProfileDir         string
+	ManualLogin        bool
+	Progress           bool
+	JSON               bool
+	Include            string
+	Limit              int
+	Exclude            string
+	ListOutput         bool
+	FilenameTemplate   string
+	Checksum           bool
+	Report             string
+	KeepTemp           bool
+	PostHook           string
+	Webhook            string
+	Since              string
+	DebugScreenshot    string
+	DebugHTML          string
+	LoginWait          time.Duration
+	ListBrowsers       bool
+	CaptureNetwork     bool
+	ExportCookiesJSON  string
+	MaxRuntime         time.Duration
+	CookiesAuthHeader  string
+	Platforms          string
+	IgnoreState        bool
+	AuthToken          string
+	OutputTemplate     string
+	CheckAuth          bool
+	Human              bool
+	HumanSeed          int64
+	PrintURLs          bool
+	Overwrite          bool
+	IncludeFeed        bool
+	ListFormats        bool
+	ExportFeed         string
+	MaxFilesize        string
+	ChromeFlags        []string
+	NoChromeFlags      []string
+	WriteThumbnail     bool
+	UpdateYtDlp        bool
+	DownloadDelay      time.Duration
+	HostResolverRules  string
+	ValidateCookies    bool
+	NumberFiles        bool
+	CacheHTML          string
+	FromHTML           string
+}
+
+// logLevel maps the -quiet and -verbose flags to a log level
+func (c Config) logLevel() logLevel {
+	switch {
+	// JSON consumers only need errors on stderr unless they ask for more
+	case c.Quiet, c.JSON && !c.Verbose:
+		return levelQuiet
+	case c.Verbose:
+		return levelVerbose
+	default:
+		return levelNormal
+	}
+}
+
+// Main runs the skool-downloader command line with the process arguments
+func Main() {
+	config := parseFlags()
+	console = newLogger(config.logLevel(), os.Stdout)
+	timings = newStageTimer(config.Profile || config.ProfileFile != "")
+
+	// Keep stdout for JSON events or -print-urls only; everything else goes to stderr
+	if config.JSON {
+		console = newLogger(config.logLevel(), os.Stderr)
+		events = newEventEmitter(os.Stdout)
+	}
+	if config.PrintURLs {
+		console = newLogger(config.logLevel(), os.Stderr)
+	}
+	if console.enabled(levelNormal) && !config.JSON && !config.PrintURLs {
+		printBanner()
+	}
+
+	if err := Run(config); err != nil {
+		if errors.Is(err, ErrMissingURL) {
+			printUsage()
+			os.Exit(1)
+		}
+		log.Fatalf("Error: %v", err)
+	}
+}
+
+// Environment variables read when the matching flag isn't set, which keeps
+// secrets out of process listings
+const (
+	urlEnv      = "SKOOL_URL"
+	cookiesEnv  = "SKOOL_COOKIES"
+	emailEnv    = "SKOOL_EMAIL"
+	passwordEnv = "SKOOL_PASSWORD"
+)
+
+// ErrMissingURL is returned when -url (Config.SkoolURL) isn't set
+var ErrMissingURL = errors.New("-url is required")
+
+// Run validates config, scrapes the classroom and downloads its videos. It
+// returns an error instead of exiting so the flow can be embedded and tested.
+func Run(config Config) (err error) {
+	// Listing browsers is a standalone utility mode that doesn't need -url
+	if config.ListBrowsers {
+		return listBrowsers(os.Stdout, getBrowserCandidates(), resolveBrowserCandidate)
+	}
+
+	// The cookie utility modes below return before validateConfig, but they
+	// read -cookies too
+	if config.ExportCookiesJSON != "" || config.ValidateCookies || config.EncryptCookies != "" {
+		if err := validateCookiesFlags(config); err != nil {
+			return err
+		}
+	}
+
+	// Converting cookies to JSON is a standalone utility mode that doesn't need -url
+	if config.ExportCookiesJSON != "" {
+		if config.CookiesFile == "" {
+			return errors.New("-export-cookies-json requires -cookies")
+		}
+		count, err := exportCookiesJSON(config, os.Stdin, config.ExportCookiesJSON)
+		if err != nil {
+			return fmt.Errorf("failed to convert cookies: %v", err)
+		}
+		console.Infof("Converted %d cookie(s)", count)
+		console.Result("JSON cookies written to:", config.ExportCookiesJSON)
+		return nil
+	}
+
+	// Validating cookies is a standalone utility mode that doesn't need -url
+	// or a browser
+	if config.ValidateCookies {
+		return checkCookiesFile(os.Stdout, os.Stdin, config)
+	}
+
+	// Encrypting cookies is a standalone utility mode that doesn't need -url
+	if config.EncryptCookies != "" {
+		if config.CookiesFile == "" || config.CookiesPassword == "" {
+			return fmt.Errorf("-encrypt-cookies requires -cookies and -cookies-password (or %s)", cookiesPasswordEnv)
+		}
+		if err := encryptCookiesFile(config.CookiesFile, config.EncryptCookies, config.CookiesPassword); err != nil {
+			return fmt.Errorf("failed to encrypt cookies: %v", err)
+		}
+		console.Result("Encrypted cookies written to:", config.EncryptCookies)
+		return nil
+	}
+
+	if err := validateConfig(&config); err != nil {
+		return err
+	}
+	defer reportTimings(config.ProfileFile)
+	human = newHumanizer(config.Human, config.HumanSeed)
+
+	// Everything from here on, scraping and downloads, stops at -max-runtime
+	ctx, cancel := runContext(config.MaxRuntime)
+	defer cancel()
+
+	report := &downloadReport{}
+	if config.Webhook != "" {
+		start := time.Now()
+		// Deferred so the webhook fires whether the run succeeds or fails
+		defer func() {
+			payload := newWebhookPayload(report, config.OutputDir, time.Since(start), err)
+			if hookErr := postWebhook(config.Webhook, payload); hookErr != nil {
+				console.Warningf("Failed to send webhook: %v", hookErr)
+			}
+		}()
+	}
+
+	// Resolve yt-dlp up front so a missing binary fails before the browser
+	// launches. -check-auth and -print-urls only need it to read cookies from
+	// a browser.
+	if !(config.CheckAuth || config.PrintURLs) || config.CookiesFromBrowser != "" {
+		ytDlpPath, err := resolveYtDlp(config.YtDlpPath)
+		if err != nil {
+			return err
+		}
+		config.YtDlpPath = ytDlpPath
+
+		// Only update when asked, an old version is just a warning
+		if config.UpdateYtDlp {
+			if err := updateYtDlp(config.YtDlpPath); err != nil {
+				console.Warning(err)
+			}
+		}
+		checkYtDlpVersion(config.YtDlpPath, time.Now())
+	}
+
+	// Stage cookies piped via stdin in a temp file so both the browser and yt-dlp can read them
+	if config.CookiesFile == stdinCookiesPath {
+		tmpFile, err := stageStdinCookies(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read cookies from stdin: %v", err)
+		}
+		defer removeTempFile(tmpFile, config.KeepTemp)
+		config.CookiesFile = tmpFile
+	}
+
+	// Merge a comma separated list of cookies files into one, so the browser
+	// and yt-dlp both get every cookie
+	if paths := splitCookiesPaths(config.CookiesFile); paths != nil {
+		tmpFile, count, err := stageMergedCookies(paths, config)
+		if err != nil {
+			return fmt.Errorf("failed to merge cookies: %v", err)
+		}
+		defer removeTempFile(tmpFile, config.KeepTemp)
+		console.Authf("Merged %d cookie(s) from %d files", count, len(paths))
+		// The merged file is plain JSON whatever the inputs were
+		config.CookiesFile = tmpFile
+		config.CookiesFormat, config.CookiesPassword, config.SameSiteZero = cookiesFormatAuto, "", sameSiteZeroUnset
+	}
+
+	// Fetch cookies kept behind an HTTP endpoint once, staged like stdin cookies
+	if isCookiesURL(config.CookiesFile) {
+		tmpFile, err := stageRemoteCookies(config.CookiesFile, config.CookiesAuthHeader)
+		if err != nil {
+			return fmt.Errorf("failed to fetch cookies from %s: %v", config.CookiesFile, err)
+		}
+		defer removeTempFile(tmpFile, config.KeepTemp)
+		config.CookiesFile = tmpFile
+	}
+
+	// Read cookies straight from an installed browser, staged like a -cookies file
+	if config.CookiesFromBrowser != "" {
+		cookies, tmpFile, err := loadCookiesFromBrowser(config.CookiesFromBrowser, config.YtDlpPath)
+		if err != nil {
+			return fmt.Errorf("failed to read cookies from browser: %v", err)
+		}
+		defer removeTempFile(tmpFile, config.KeepTemp)
+		console.Authf("Read %d skool.com cookie(s) from %s", len(cookies), config.CookiesFromBrowser)
+		config.CookiesFile = tmpFile
+	}
+
+	if config.CheckAuth {
+		return checkAuth(ctx, config)
+	}
+
+	if config.FromHTML == "" {
+		console.Info("Scraping videos from:", config.SkoolURL)
+	}
+
+	// Scrape videos based on auth method
+	result, err := scrapeVideos(ctx, config)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return fmt.Errorf("-max-runtime of %s reached while scraping", config.MaxRuntime)
+		}
+		return fmt.Errorf("scraping failed: %v", err)
+	}
+	report.Extraction = append(report.Extraction, extractionSummary{URL: config.SkoolURL, Source: result.Source, Videos: len(result.Videos)})
+	// The same video can be linked from several lessons, download it once
+	videos, duplicates := dedupeVideos(result.Videos)
+	if duplicates > 0 {
+		console.Infof("Skipped %d duplicate video(s) linked from more than one lesson", duplicates)
+	}
+
+	// Number before filtering so a lesson keeps its number whatever is selected
+	if config.NumberFiles {
+		numberVideos(videos)
+	}
+
+	// -print-urls, -list-output, -list-formats and -export-feed only report on
+	// the selected videos, so they neither create folders nor skip what an
+	// earlier run downloaded
+	downloading := !config.PrintURLs && !config.ListOutput && !config.ListFormats && config.ExportFeed == ""
+
+	// Keep each course in its own folder, downloads/<community>/<course>/ by default
+	statePath := filepath.Join(config.OutputDir, stateFileName)
+	config.OutputDir = result.Course.OutputDir(config.OutputDir, config.OutputTemplate)
+
+	state, err := loadStateStore(statePath)
+	if err != nil {
+		return fmt.Errorf("failed to read state file %s: %v (delete it to start over)", statePath, err)
+	}
+
+	if len(videos) == 0 {
+		// Only an empty course tree means there's nothing to download; no
+		// course data at all points at the session or the page load
+		if err := noVideosError(result.Source); err != nil {
+			return err
+		}
+		console.Warning("The course has no video lessons.")
+		events.Emit(Event{Type: eventSummary})
+		return nil
+	}
+
+	console.Successf("Found %d video(s)", len(videos))
+
+	// Platform names were already checked in validateConfig
+	if platforms, _ := parsePlatforms(config.Platforms); platforms != nil {
+		var removed int
+		videos, removed = filterPlatforms(videos, platforms)
+		console.Infof("Filtered out %d video(s) hosted outside %s, %d left", removed, config.Platforms, len(videos))
+		if len(videos) == 0 {
+			console.Error("No videos left after applying -platforms.")
+			events.Emit(Event{Type: eventSummary})
+			return nil
+		}
+	}
+
+	// Regexes were already checked in validateConfig
+	filter, _ := newTitleFilter(config.Include, config.Exclude)
+	if filter != nil {
+		var removed int
+		videos, removed = filterVideos(videos, filter)
+		console.Infof("Filtered out %d lesson(s) by title, %d left", removed, len(videos))
+		if len(videos) == 0 {
+			console.Error("No videos left after applying -include/-exclude.")
+			events.Emit(Event{Type: eventSummary})
+			return nil
+		}
+	}
+
+	if config.Since != "" {
+		// Already checked in validateConfig
+		since, _ := parseSince(config.Since)
+		var removed, undated int
+		videos, removed, undated = filterSince(videos, since)
+		console.Infof("Filtered out %d lesson(s) added before %s, %d left", removed, config.Since, len(videos))
+		if undated > 0 {
+			console.Warningf("%d lesson(s) have no date in the course data and were kept", undated)
+		}
+		if len(videos) == 0 {
+			console.Error("No videos left after applying -since.")
+			events.Emit(Event{Type: eventSummary})
+			return nil
+		}
+	}
+
+	// -overwrite downloads again what an earlier run got, so the state is skipped
+	if !config.IgnoreState && !config.Overwrite && downloading {
+		var done int
+		videos, done = filterDownloaded(videos, state, config.SkoolURL)
+		if done > 0 {
+			console.Infof("Skipping %d video(s) downloaded in an earlier run, %d left", done, len(videos))
+		}
+		if len(videos) == 0 {
+			console.Success("Every video has already been downloaded, pass -ignore-state to download them again")
+			events.Emit(Event{Type: eventSummary})
+			return nil
+		}
+	}
+
+	if config.Limit > 0 && len(videos) > config.Limit {
+		console.Infof("Limiting to the first %d of %d video(s)", config.Limit, len(videos))
+		videos = limitVideos(videos, config.Limit)
+	}
+
+	for i, video := range videos {
+		events.Emit(Event{Type: eventVideo, Index: i + 1, Total: len(videos), URL: video.URL, Title: video.Title})
+	}
+
+	if config.PrintURLs {
+		return printVideoURLs(os.Stdout, videos)
+	}
+
+	if config.ListOutput {
+		listOutputFiles(videos, config)
+		return nil
+	}
+
+	if config.ListFormats {
+		listVideoFormats(os.Stdout, videos, config)
+		return nil
+	}
+
+	if config.ExportFeed != "" {
+		if err := writeFeed(config.ExportFeed, buildFeed(result.Course, config.SkoolURL, videos)); err != nil {
+			return fmt.Errorf("failed to write feed: %v", err)
+		}
+		console.Successf("Wrote a feed of %d video(s) to %s", len(videos), config.ExportFeed)
+		return nil
+	}
+
+	if err := os.MkdirAll(config.OutputDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %v", err)
+	}
+	console.Info("Saving videos to:", config.OutputDir)
+
+	if config.Overwrite {
+		console.Info("Existing files: overwritten (-overwrite)")
+	} else {
+		console.Info("Existing files: skipped by yt-dlp (use -overwrite to replace them)")
+	}
+
+	// Download each video
+	breaker := newCircuitBreaker(config.SkipOnErrorCount)
+	proxies := newProxyRotator(splitProxies(config.Proxy))
+	for i, video := range videos {
+		pauseBeforeDownload(ctx, i, config.DownloadDelay, sleepContext)
+		if ctx.Err() != nil {
+			err := skipAfterDeadline(report, videos[i:], len(videos), config.MaxRuntime)
+			writeReport(report, config)
+			return err
+		}
+		url := video.URL
+		console.Blank()
+		if video.Title != "" {
+			console.Downloadf("[%d/%d] %s (%s)", i+1, len(videos), video.Title, url)
+		} else {
+			console.Downloadf("[%d/%d] %s", i+1, len(videos), url)
+		}
+		events.Emit(Event{Type: eventDownloadStart, Index: i + 1, Total: len(videos), URL: url, Title: video.Title})
+		stopTimer := timings.Start(fmt.Sprintf("download %d: %s", i+1, url))
+		var bar *progressBar
+		if config.Progress {
+			bar = newProgressBar(os.Stdout, i+1, len(videos))
+		}
+		downloadConfig := config
+		downloadConfig.Proxy = proxies.Next()
+		if downloadConfig.Proxy != "" {
+			console.Debug("Downloading through proxy:", downloadConfig.Proxy)
+		}
+		file, err := downloadWithYtDlp(ctx, video, downloadConfig, bar)
+		stopTimer()
+		if err != nil && ctx.Err() != nil {
+			// yt-dlp was killed at the deadline, so this video didn't finish either
+			err = skipAfterDeadline(report, videos[i:], len(videos), config.MaxRuntime)
+			writeReport(report, config)
+			return err
+		}
+		if errors.Is(err, errTooLarge) {
+			console.Warning(err)
+		} else if err != nil {
+			console.Error(err)
+		}
+		checksum := ""
+		if err == nil && config.Checksum {
+			checksum = downloadChecksum(file)
+		}
+		if err == nil && config.WriteThumbnail {
+			ensureThumbnail(video, file)
+		}
+		if err == nil && config.PostHook != "" {
+			if file == "" {
+				console.Warning("Skipping post-hook, yt-dlp didn't report where the video was saved")
+			} else if hookErr := runPostHook(config.PostHook, file, video); hookErr != nil {
+				console.Warning(hookErr)
+			}
+		}
+		if err == nil {
+			state.MarkDownloaded(config.SkoolURL, video)
+			if saveErr := state.Save(); saveErr != nil {
+				console.Warningf("Failed to save state file: %v", saveErr)
+			}
+		}
+		report.Add(video, file, checksum, err)
+		events.DownloadResult(i+1, len(videos), url, file, err)
+
+		// A video over -max-filesize was skipped on purpose, not a failure
+		if breaker.Record(err == nil || errors.Is(err, errTooLarge)) {
+			report.Skip(videos[i+1:])
+			writeReport(report, config)
+			return fmt.Errorf("aborting after %d consecutive failed downloads (%d of %d videos not attempted). "+
+				"This usually means authentication is not working: re-export your cookies or log in with -email/-password",
+				config.SkipOnErrorCount, len(videos)-i-1, len(videos))
+		}
+	}
+
+	writeReport(report, config)
+
+	succeeded, failed, _ := report.Counts()
+	console.Blank()
+	console.Result("Download process completed:", report.Summary())
+	for _, e := range report.Extraction {
+		console.Infof("Extracted %d video(s) from %s via %s", e.Videos, e.URL, e.Source)
+	}
+	events.Emit(Event{Type: eventSummary, Total: len(videos), Succeeded: succeeded, Failed: failed})
+	return nil
+}
+
+// downloadChecksum returns the SHA-256 of a downloaded file, or "" if it
+// can't be read
+func downloadChecksum(file string) string {
+	if file == "" {
+		console.Warning("Skipping checksum, yt-dlp didn't report where the video was saved")
+		return ""
+	}
+	sum, err := hashFile(file)
+	if err != nil {
+		console.Warningf("Failed to compute checksum of %s: %v", file, err)
+		return ""
+	}
+	console.Debugf("SHA-256 of %s: %s", file, sum)
+	return sum
+}
+
+// writeReport writes the download report to -report, or to report.txt in the
+// output directory
+func writeReport(report *downloadReport, config Config) {
+	path := config.Report
+	if path == "" {
+		path = filepath.Join(config.OutputDir, defaultReportName)
+	}
+	if err := report.WriteFile(path); err != nil {
+		console.Warningf("Failed to write download report: %v", err)
+		return
+	}
+	console.Info("Report written to:", path)
+}
+
+// reportTimings prints the -profile breakdown, or writes it to path when set
+func reportTimings(path string) {
+	if path == "" {
+		_ = timings.Report(console.out)
+		return
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		console.Warningf("Failed to write timing profile: %v", err)
+		return
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if err := timings.Report(file); err != nil {
+		console.Warningf("Failed to write timing profile: %v", err)
+		return
+	}
+	console.Info("Timing profile written to:", path)
+}
+
+func printBanner() {
+	fmt.Println(`
+ ______     __  __     ______     ______     __            _____     __       
+/\  ___\   /\ \/ /    /\  __ \   /\  __ \   /\ \          /\  __-.  /\ \      
+\ \___  \  \ \  _"-.  \ \ \/\ \  \ \ \/\ \  \ \ \____     \ \ \/\ \ \ \ \____ 
+ \/\_____\  \ \_\ \_\  \ \_____\  \ \_____\  \ \_____\     \ \____-  \ \_____\
+  \/_____/   \/_/\/_/   \/_____/   \/_____/   \/_____/      \/____/   \/_____/
+  		
+  			Skool.com Video Downloader
+		
+			by Fx64b - github.com/fx64b
+    `)
+}
+
+func parseFlags() Config {
+	// flag.CommandLine exits on parse errors, so there's no error to handle
+	config, _ := parseArgs(flag.CommandLine, os.Args[1:])
+	return config
+}
+
+// DefaultConfig returns the Config the command line starts from, with every
+// option at its flag default and nothing read from the environment
+func DefaultConfig() Config {
+	var config Config
+	defineFlags(flag.NewFlagSet("skool-downloader", flag.ContinueOnError), &config)
+	return config
+}
+
+// parseArgs parses args into a Config using the flags defined on fs
+func parseArgs(fs *flag.FlagSet, args []string) (Config, error) {
+	var config Config
+	defineFlags(fs, &config)
+
+	if err := fs.Parse(args); err != nil {
+		return config, err
+	}
+
+	// Flags win, the environment fills in whatever wasn't passed. Credentials
+	// from the environment are only used when no sign-in method was passed,
+	// so they can't take over from or conflict with the one on the command line.
+	authPassed := false
+	fs.Visit(func(f *flag.Flag) {
+		for _, name := range authFlags {
+			if f.Name == name {
+				authPassed = true
+			}
+		}
+	})
+	for _, fallback := range []struct {
+		value *string
+		env   string
+		auth  bool
+	}{
+		{&config.SkoolURL, urlEnv, false},
+		{&config.CookiesFile, cookiesEnv, true},
+		{&config.Email, emailEnv, true},
+		{&config.Password, passwordEnv, true},
+		{&config.CookiesPassword, cookiesPasswordEnv, false},
+		{&config.AuthToken, authTokenEnv, true},
+		{&config.TOTPSecret, totpSecretEnv, false},
+	} {
+		if *fallback.value == "" && !(fallback.auth && authPassed) {
+			*fallback.value = os.Getenv(fallback.env)
+		}
+	}
+	// The header only means something for cookies fetched from a URL
+	if config.CookiesAuthHeader == "" && isCookiesURL(config.CookiesFile) {
+		config.CookiesAuthHeader = os.Getenv(cookiesAuthEnv)
+	}
+
+	return config, nil
+}
+
+// defineFlags defines every command line flag on fs, storing into config
+func defineFlags(fs *flag.FlagSet, config *Config) {
+	fs.StringVar(&config.SkoolURL, "url", "", "URL of the skool.com classroom to scrape (required, or set "+urlEnv+")")
+	fs.StringVar(&config.CookiesFile, "cookies", "", "Path to cookies file (JSON or TXT) for authentication, several comma separated files to merge, an http(s) URL to fetch it from, or - to read from stdin (or set "+cookiesEnv+")")
+	fs.StringVar(&config.AuthToken, "auth-token", "", "Value of the skool.com auth_token cookie, instead of a whole cookies file (or set "+authTokenEnv+")")
+	fs.StringVar(&config.CookiesAuthHeader, "cookies-auth-header", "", "Authorization header sent when -cookies is a URL, e.g. \"Bearer <token>\" (or set "+cookiesAuthEnv+")")
+	fs.StringVar(&config.TOTPSecret, "totp-secret", "", "Base32 authenticator secret for accounts with two-factor authentication (or set "+totpSecretEnv+")")
+	fs.StringVar(&config.CookiesFromBrowser, "cookies-from-browser", "", "Read skool.com cookies from an installed browser's cookie store via yt-dlp (e.g. chrome, firefox, edge, chrome:Profile 1)")
+	fs.StringVar(&config.CookiesFormat, "cookies-format", cookiesFormatAuto, "Format of the -cookies file: auto, json or netscape")
+	fs.StringVar(&config.SameSiteZero, "cookies-samesite-zero", sameSiteZeroUnset, "How a sameSite of 0 in JSON cookies is read: unset (browser default) or none (Firefox numbering)")
+	fs.StringVar(&config.CookiesPassword, "cookies-password", "", "Password for an encrypted cookies file (or set "+cookiesPasswordEnv+")")
+	fs.BoolVar(&config.CheckAuth, "check-auth", false, "Check that the cookies, -auth-token or -profile-dir still log in to Skool, then exit (0 if logged in); doesn't need -url")
+	fs.BoolVar(&config.ListBrowsers, "list-browsers", false, "List the browsers auto-detection looks for, which are installed and which would be used, then exit")
+	fs.StringVar(&config.ExportCookiesJSON, "export-cookies-json", "", "Convert the -cookies file (Netscape or JSON) to this tool's JSON format, write it to this path and exit")
+	fs.BoolVar(&config.ValidateCookies, "validate-cookies", false, "Parse the -cookies file, print a summary of it and whether it holds a Skool session, then exit")
+	fs.StringVar(&config.EncryptCookies, "encrypt-cookies", "", "Encrypt the -cookies file with -cookies-password, write it to this path and exit")
+	fs.StringVar(&config.Email, "email", "", "Email for Skool login (alternative to cookies, or set "+emailEnv+")")
+	fs.StringVar(&config.Password, "password", "", "Password for Skool login (required with email, or set "+passwordEnv+")")
+	fs.BoolVar(&config.ManualLogin, "manual-login", false, "Open a visible browser and wait for you to log in by hand (alternative to email or cookies)")
+	fs.StringVar(&config.OutputDir, "output", defaultOutputDir, "Base directory for downloads, each course is saved in <output>/<community>/<course>/ (see -output-template)")
+	fs.StringVar(&config.OutputTemplate, "output-template", defaultOutputTemplate, "Folder for each course inside -output, {community} and {course} are filled in from the course, empty saves straight into -output")
+	fs.IntVar(&config.WaitTime, "wait", defaultWaitTime, "Longest time to wait for a page to load in seconds, pages that are ready sooner don't wait")
+	fs.IntVar(&config.NextDataRetries, "next-data-retries", defaultNextDataRetries, "Times to re-read the page while Skool's course data hasn't loaded yet")
+	fs.DurationVar(&config.LoginWait, "login-wait", defaultLoginWait, "How long each -email/-password login step waits for the page, e.g. 30s")
+	fs.DurationVar(&config.Timeout, "timeout", browserTimeout, "Maximum time for the browser session, e.g. 90s or 10m")
+	fs.DurationVar(&config.DownloadDelay, "download-delay", 0, "Wait this long between downloads to avoid throttling, e.g. 10s (0 means no wait)")
+	fs.DurationVar(&config.MaxRuntime, "max-runtime", 0, "Stop the whole run, scraping and downloads, after this long, e.g. 2h (0 means no limit)")
+	fs.BoolVar(&config.Headless, "headless", defaultHeadless, "Run in headless mode (no browser UI)")
+	fs.StringVar(&config.ProfileDir, "profile-dir", "", "Browser profile directory to keep the Skool session between runs (anyone who can read it can use your account)")
+	fs.StringVar(&config.BrowserPath, "browser", "", "Path or command of a Chromium-based browser to use (auto-detected if not specified)")
+	fs.StringVar(&config.Engine, "engine", "", "Browser engine of -browser, only chromium is supported; set it for a Chromium wrapper whose name contains \"firefox\"")
+	fs.StringVar(&config.HostResolverRules, "host-resolver-rules", "", "Chromium host resolver rules for the browser, e.g. \"MAP www.skool.com 203.0.113.7\"")
+	fs.Var((*stringListFlag)(&config.ChromeFlags), "chrome-flag", "Extra Chromium flag as name or name=value, can be repeated")
+	fs.Var((*stringListFlag)(&config.NoChromeFlags), "no-chrome-flag", "Default Chromium flag to leave out, e.g. no-sandbox, can be repeated")
+	fs.StringVar(&config.YtDlpPath, "ytdlp", defaultYtDlpPath, "Path or command of the yt-dlp executable")
+	fs.BoolVar(&config.UpdateYtDlp, "update-ytdlp", false, "Update yt-dlp with yt-dlp -U before downloading")
+	fs.IntVar(&config.SkipOnErrorCount, "skip-on-error-count", 0, "Abort the run after this many consecutive failed downloads (0 = never)")
+	fs.BoolVar(&config.Human, "human", false, "Randomize waits and move the mouse before extraction so the browser looks less automated (slower)")
+	fs.Int64Var(&config.HumanSeed, "human-seed", 0, "Seed for -human's randomness, for reproducible runs (0 picks a new one each run)")
+	fs.StringVar(&config.UserAgent, "user-agent", defaultUserAgent, "User-Agent sent by the browser and yt-dlp")
+	fs.StringVar(&config.Proxy, "proxy", "", "Proxy URL (http, https or socks5) used by both the browser and yt-dlp, or several comma separated to rotate downloads through")
+	fs.StringVar(&config.Recode, "recode", "", "Re-encode every video to this format with ffmpeg: mp4, mkv, webm or mov (slower)")
+	fs.StringVar(&config.RateLimit, "rate-limit", "", "Maximum download rate per video passed to yt-dlp, e.g. 500K or 2M")
+	fs.StringVar(&config.MaxFilesize, "max-filesize", "", "Skip videos larger than this size, e.g. 500M or 2G")
+	fs.BoolVar(&config.EmbedMetadata, "embed-metadata", false, "Embed the lesson title, metadata and thumbnail in each video and name files after the lesson (requires ffmpeg)")
+	fs.BoolVar(&config.WriteThumbnail, "write-thumbnail", false, "Save each video's thumbnail as an image file next to it")
+	fs.StringVar(&config.YtDlpArgs, "ytdlp-args", "", "Extra arguments passed verbatim to yt-dlp for every video, quoted like a shell command line")
+	fs.BoolVar(&config.ForceIPv4, "force-ipv4", false, "Make yt-dlp connect over IPv4 only (fixes stalled downloads on some networks)")
+	fs.BoolVar(&config.Quiet, "quiet", false, "Only print errors and the final result")
+	fs.BoolVar(&config.Verbose, "verbose", false, "Print debug details, including the browser's internal logs")
+	fs.BoolVar(&config.JSON, "json", false, "Print newline-delimited JSON events to stdout instead of the usual output (errors go to stderr)")
+	fs.BoolVar(&config.Progress, "progress", false, "Show a single progress bar across all downloads instead of yt-dlp's output")
+	fs.StringVar(&config.Include, "include", "", "Only download lessons whose title matches this regular expression")
+	fs.StringVar(&config.Platforms, "platforms", "", "Only download videos hosted on these platforms, comma separated, e.g. loom,youtube (default: all)")
+	fs.StringVar(&config.Exclude, "exclude", "", "Skip lessons whose title matches this regular expression (wins over -include)")
+	fs.IntVar(&config.Limit, "limit", 0, "Only download the first N videos, after filtering (0 = no limit)")
+	fs.StringVar(&config.FilenameTemplate, "filename-template", defaultFilenameTemplate, "yt-dlp output template for file names inside the output directory, {module} and {lesson} are filled in from the course")
+	fs.BoolVar(&config.NumberFiles, "number-files", false, "Prefix file names with the lesson's position in the course, e.g. \"01.03 - \"")
+	fs.BoolVar(&config.ListOutput, "list-output", false, "Print the file each video would be saved as, without downloading")
+	fs.BoolVar(&config.ListFormats, "list-formats", false, "Print the formats yt-dlp offers for each video, without downloading")
+	fs.StringVar(&config.ExportFeed, "export-feed", "", "Write an RSS feed of the course's videos to this file, without downloading")
+	fs.BoolVar(&config.PrintURLs, "print-urls", false, "Print only the video URLs to stdout, one per line, without downloading (logs go to stderr)")
+	fs.BoolVar(&config.Overwrite, "overwrite", false, "Download videos again even if the file already exists (default: keep existing files)")
+	fs.BoolVar(&config.IncludeFeed, "include-feed", false, "Also download videos posted in the community feed (scrolls through older posts, slower)")
+	fs.BoolVar(&config.IgnoreState, "ignore-state", false, "Download videos again even if "+stateFileName+" in the output directory says an earlier run got them")
+	fs.BoolVar(&config.Checksum, "checksum", false, "Record the SHA-256 of each downloaded file in the report")
+	fs.StringVar(&config.Report, "report", "", "Write a summary of each download to this file, .json for JSON (default: report.txt in the output directory)")
+	fs.BoolVar(&config.CaptureNetwork, "capture-network", false, "Experimental: play videos yt-dlp can't resolve in the browser and download the stream they request")
+	fs.BoolVar(&config.Deep, "deep", false, "Visit every lesson of the course to find videos in collapsed modules (slower)")
+	fs.IntVar(&config.Tabs, "tabs", 1, fmt.Sprintf("Number of browser tabs -deep visits lessons in at once (1-%d)", maxTabs))
+	fs.StringVar(&config.DebugScreenshot, "debug-screenshot", "", "Save a full-page PNG screenshot here when scraping fails or finds no videos")
+	fs.StringVar(&config.CacheHTML, "cache-html", "", "Save the classroom HTML here after loading it, for -from-html")
+	fs.StringVar(&config.FromHTML, "from-html", "", "Extract videos from a classroom page saved with -cache-html instead of opening a browser")
+	fs.StringVar(&config.DebugHTML, "debug-html", "", "Save the page HTML here when scraping fails or finds no videos")
+	fs.StringVar(&config.Since, "since", "", "Only download lessons added on or after this date (YYYY-MM-DD)")
+	fs.StringVar(&config.Webhook, "webhook", "", "POST a JSON summary of the run to this URL when it finishes")
+	fs.StringVar(&config.PostHook, "post-hook", "", "Command run after each successful download with the file path and lesson title as arguments")
+	fs.BoolVar(&config.KeepTemp, "keep-temp", false, "Keep the temporary cookie files given to yt-dlp and print their paths, for debugging")
+	fs.BoolVar(&config.Profile, "profile", false, "Print how long each stage (browser launch, navigation, extraction, downloads) took")
+	fs.StringVar(&config.ProfileFile, "profile-file", "", "Write the -profile timing breakdown to this file instead of printing it")
+	fs.StringVar(&config.SaveCookies, "save-cookies", "", "After a successful email/password or manual login, save the session cookies to this JSON file for use with -cookies")
+	fs.StringVar(&config.ExportCookies, "export-cookies", "", "Export the browser's skool.com cookies after scraping (.json for this tool, .sql for Firefox's cookies.sqlite)")
+}
+
+// authFlags are the flags that pick a sign-in method
+var authFlags = []string{"cookies", "auth-token", "cookies-from-browser", "email", "password", "manual-login", "profile-dir"}
+
+// printUsage prints the flag summary shown when -url is missing
+func printUsage() {
+	fmt.Println("Usage: skool-downloader -url=https://skool.com/yourschool/classroom/path [-cookies=cookies.json | -email=user@example.com -password=pass] [-browser=/path/to/browser]")
+	fmt.Println()
+	fmt.Println("Flags:")
+	fmt.Println("  -url        Skool classroom URL to scrape (required, or set " + urlEnv + ")")
+	fmt.Println("  -email      Email address for Skool login (or set " + emailEnv + ")")
+	fmt.Println("  -password   Password for Skool login (required with -email, or set " + passwordEnv + ")")
+	fmt.Println("  -manual-login  Open a visible browser and wait for you to log in by hand")
+	fmt.Println("  -totp-secret  Base32 authenticator secret for accounts with 2FA (or set " + totpSecretEnv + ")")
+	fmt.Println("  -cookies    Path to cookies file (JSON or Netscape .txt), comma separated files to merge, an http(s) URL to fetch it from, or - to read from stdin (or set " + cookiesEnv + ")")
+	fmt.Println("  -auth-token  Value of the skool.com auth_token cookie, instead of a whole cookies file (or set " + authTokenEnv + ")")
+	fmt.Println("  -check-auth  Check that the cookies, -auth-token or -profile-dir still log in, then exit (0 if logged in, -url not needed)")
+	fmt.Println("  -cookies-auth-header  Authorization header sent when -cookies is a URL, e.g. \"Bearer <token>\" (or set " + cookiesAuthEnv + ")")
+	fmt.Println("  -cookies-from-browser  Read skool.com cookies from an installed browser via yt-dlp")
+	fmt.Println("                         (" + strings.Join(cookieBrowsers, ", ") + ", optionally with :PROFILE)")
+	fmt.Println("  -cookies-format  Format of the -cookies file: auto, json or netscape (default: auto, from the extension or content)")
+	fmt.Println("  -cookies-samesite-zero  How a sameSite of 0 in JSON cookies is read: unset (default) or none (Firefox numbering)")
+	fmt.Println("  -cookies-password  Password for an encrypted cookies file (or set " + cookiesPasswordEnv + ")")
+	fmt.Println("  -export-cookies-json  Convert the -cookies file (Netscape or JSON) to this tool's JSON format, write it to this path and exit")
+	fmt.Println("  -validate-cookies  Parse the -cookies file, print a summary and whether it holds a Skool session, then exit")
+	fmt.Println("  -encrypt-cookies   Encrypt the -cookies file with the cookies password, write it to this path and exit")
+	fmt.Println("  -output     Base directory, videos go into <output>/<community>/<course>/ (default: \"downloads\")")
+	fmt.Println("  -output-template  Folder for each course inside -output, empty for none (default: \"" + defaultOutputTemplate + "\")")
+	fmt.Println("  -wait       Longest time to wait for a page to load in seconds, pages that are ready sooner don't wait (default: 2)")
+	fmt.Println("  -next-data-retries  Times to re-read the page while Skool's course data hasn't loaded (default: 3)")
+	fmt.Println("  -login-wait How long each -email/-password login step waits for the page (default: 15s)")
+	fmt.Println("  -timeout    Maximum time for the browser session, e.g. 90s or 10m (default: 3m0s)")
+	fmt.Println("  -download-delay  Wait this long between downloads to avoid throttling, e.g. 10s (default: no wait)")
+	fmt.Println("  -max-runtime  Stop the whole run, scraping and downloads, after this long, e.g. 2h (default: no limit)")
+	fmt.Println("  -headless   Run browser in headless mode (default: true)")
+	fmt.Println("  -browser    Path or command of a Chromium-based browser (auto-detected if not set)")
+	fmt.Println("  -list-browsers  List the browsers auto-detection looks for and which would be used, then exit")
+	fmt.Println("              Supported: Edge, Chrome, Chromium, Brave, Vivaldi, Opera")
+	fmt.Println("              Auto-detected in this order:")
+	fmt.Println("                Windows : msedge, chrome, chromium, vivaldi, opera (PATH), then Edge, Vivaldi and Opera default installs")
+	fmt.Println("                macOS   : Chrome, Chromium, Edge, Brave (/Applications/)")
+	fmt.Println("                Linux   : chromium-browser, chromium, google-chrome, microsoft-edge, brave-browser, vivaldi, opera (PATH)")
+	fmt.Println("  -engine     Browser engine of -browser, only chromium is supported (for wrappers named like firefox)")
+	fmt.Println("  -host-resolver-rules  Chromium host resolver rules for the browser, e.g. \"MAP www.skool.com 203.0.113.7\"")
+	fmt.Println("  -chrome-flag  Extra Chromium flag as name or name=value, can be repeated")
+	fmt.Println("  -no-chrome-flag  Default Chromium flag to leave out (headless, disable-gpu, no-sandbox, window-size), can be repeated")
+	fmt.Println("  -profile-dir  Keep the browser profile, and the Skool session, in this directory between runs")
+	fmt.Println("                Anyone who can read the directory can use your account, keep it private")
+	fmt.Println("  -ytdlp      Path or command of the yt-dlp executable (default: \"yt-dlp\")")
+	fmt.Println("  -update-ytdlp  Update yt-dlp with yt-dlp -U before downloading")
+	fmt.Println("  -skip-on-error-count  Abort after this many consecutive failed downloads (default: 0, never)")
+	fmt.Println("  -user-agent User-Agent sent by the browser and yt-dlp (default: a current desktop Chrome)")
+	fmt.Println("  -human      Randomize waits and move the mouse before extraction so the browser looks less automated")
+	fmt.Println("  -human-seed  Seed for -human's randomness, for reproducible runs (default: a new one each run)")
+	fmt.Println("  -proxy      Proxy URL (http://, https:// or socks5://) used by both the browser and yt-dlp, or several comma separated to rotate downloads through")
+	fmt.Println("  -recode     Re-encode every video to mp4, mkv, webm or mov with ffmpeg (slower)")
+	fmt.Println("  -rate-limit Maximum download rate per video, e.g. 500K or 2M (bytes per second)")
+	fmt.Println("  -max-filesize  Skip videos larger than this size, e.g. 500M or 2G")
+	fmt.Println("  -embed-metadata  Embed the lesson title, metadata and thumbnail in each video and name files after the lesson (requires ffmpeg)")
+	fmt.Println("  -write-thumbnail  Save each video's thumbnail as an image file next to it")
+	fmt.Println("  -ytdlp-args Extra arguments passed verbatim to yt-dlp, e.g. \"--concurrent-fragments 4\"")
+	fmt.Println("  -force-ipv4 Make yt-dlp connect over IPv4 only (fixes stalled downloads on some networks)")
+	fmt.Println("  -quiet      Only print errors and the final result (useful for cron jobs)")
+	fmt.Println("  -verbose    Print debug details, including the browser's internal logs")
+	fmt.Println("  -json       Print newline-delimited JSON events to stdout (errors go to stderr)")
+	fmt.Println("  -progress   Show a single progress bar across all downloads instead of yt-dlp's output")
+	fmt.Println("  -include    Only download lessons whose title matches this regular expression")
+	fmt.Println("  -exclude    Skip lessons whose title matches this regular expression (wins over -include)")
+	fmt.Println("  -platforms  Only download videos hosted on these platforms, comma separated: " + platformNames() + " (default: all)")
+	fmt.Println("  -limit      Only download the first N videos, after filtering (default: 0, no limit)")
+	fmt.Println("  -filename-template  yt-dlp output template inside the output directory (default: \"" + defaultFilenameTemplate + "\")")
+	fmt.Println("                      {module} and {lesson} are replaced with the module and lesson titles")
+	fmt.Println("  -number-files      Prefix file names with the lesson's position in the course, e.g. \"01.03 - \"")
+	fmt.Println("  -list-output  Print the file each video would be saved as, without downloading")
+	fmt.Println("  -list-formats  Print the formats yt-dlp offers for each video, without downloading")
+	fmt.Println("  -export-feed  Write an RSS feed of the course's videos to this file, without downloading")
+	fmt.Println("  -print-urls  Print only the video URLs to stdout, one per line, without downloading (logs go to stderr)")
+	fmt.Println("  -overwrite  Download videos again even if the file already exists (default: keep existing files)")
+	fmt.Println("  -include-feed  Also download videos posted in the community feed (scrolls through older posts, slower)")
+	fmt.Println("  -ignore-state  Download videos again even if an earlier run already got them")
+	fmt.Println("  -checksum   Record the SHA-256 of each downloaded file in the report")
+	fmt.Println("  -report     Write a summary of each download to this file, .json for JSON (default: report.txt in the output directory)")
+	fmt.Println("  -capture-network  Experimental: play videos yt-dlp can't resolve in the browser and download the stream they request")
+	fmt.Println("  -deep       Visit every lesson of the course to find videos in collapsed modules (slower)")
+	fmt.Printf("  -tabs       Number of browser tabs -deep visits lessons in at once (default: 1, max: %d)\n", maxTabs)
+	fmt.Println("  -debug-screenshot  Save a full-page PNG screenshot here when scraping fails or finds no videos")
+	fmt.Println("  -debug-html Save the page HTML here when scraping fails or finds no videos")
+	fmt.Println("  -cache-html Save the classroom HTML here after loading it, for -from-html")
+	fmt.Println("  -from-html  Extract videos from a page saved with -cache-html instead of opening a browser")
+	fmt.Println("  -since      Only download lessons added on or after this date (YYYY-MM-DD)")
+	fmt.Println("  -webhook    POST a JSON summary of the run to this URL when it finishes")
+	fmt.Println("  -post-hook  Command run after each successful download with the file path and lesson title as arguments")
+	fmt.Println("  -keep-temp  Keep the temporary cookie files given to yt-dlp and print their paths, for debugging")
+	fmt.Println("  -profile    Print how long each stage (browser launch, navigation, extraction, downloads) took")
+	fmt.Println("  -profile-file  Write the -profile timing breakdown to this file instead")
+	fmt.Println("  -save-cookies  After a successful email/password or manual login, save the session cookies to this JSON file")
+	fmt.Println("  -export-cookies  Export the browser's skool.com cookies after scraping")
+	fmt.Println("                   .json: reusable with -cookies, .sql: import into Firefox's cookies.sqlite")
+}
+
+// validateConfig returns an error for invalid flag combinations and
+// normalizes the classroom URL in place
+func validateConfig(config *Config) error {
+	// -check-auth only visits skool.com itself, and -from-html reads the
+	// course from the saved page
+	if config.SkoolURL == "" && !config.CheckAuth && config.FromHTML == "" {
+		return ErrMissingURL
+	}
+
+	if config.FromHTML != "" {
+		if err := validateFromHTML(*config); err != nil {
+			return err
+		}
+	}
+
+	if config.SkoolURL != "" {
+		skoolURL, err := validateSkoolURL(config.SkoolURL)
+		if err != nil {
+			return fmt.Errorf("invalid -url: %v", err)
+		}
+		if !strings.Contains(skoolURL, "/classroom") {
+			console.Warningf("%s is not a classroom URL, videos are usually only found under %s/classroom", skoolURL, skoolURL)
+		}
+		config.SkoolURL = skoolURL
+	}
+
+	if err := validateEngine(config.Engine); err != nil {
+		return err
+	}
+
+	if config.HostResolverRules != "" {
+		if err := validateHostResolverRules(config.HostResolverRules); err != nil {
+			return err
+		}
+	}
+
+	if _, err := chromeFlags(*config); err != nil {
+		return fmt.Errorf("%v in -chrome-flag or -no-chrome-flag", err)
+	}
+
+	for _, proxy := range splitProxies(config.Proxy) {
+		if err := validateProxy(proxy); err != nil {
+			return err
+		}
+	}
+
+	if config.Since != "" {
+		if _, err := parseSince(config.Since); err != nil {
+			return err
+		}
+	}
+
+	if config.Webhook != "" {
+		if err := validateWebhook(config.Webhook); err != nil {
+			return err
+		}
+	}
+
+	if _, err := splitArgs(config.YtDlpArgs); err != nil {
+		return fmt.Errorf("invalid -ytdlp-args: %v", err)
+	}
+
+	if config.Recode != "" && !slices.Contains(recodeFormats, config.Recode) {
+		return fmt.Errorf("unsupported -recode format %q (use %s)", config.Recode, strings.Join(recodeFormats, ", "))
+	}
+
+	if config.RateLimit != "" && !rateLimitRegex.MatchString(config.RateLimit) {
+		return fmt.Errorf("invalid -rate-limit %q, use a number of bytes per second with an optional K, M or G suffix (e.g. 500K or 2M)", config.RateLimit)
+	}
+
+	if config.MaxFilesize != "" && !fileSizeRegex.MatchString(config.MaxFilesize) {
+		return fmt.Errorf("invalid -max-filesize %q, use a number of bytes with an optional K, M or G suffix (e.g. 500M or 2G)", config.MaxFilesize)
+	}
+
+	if strings.TrimSpace(config.UserAgent) == "" {
+		return errors.New("-user-agent cannot be empty")
+	}
+
+	if err := validateOutputTemplate(config.OutputTemplate); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(config.FilenameTemplate) == "" {
+		return errors.New("-filename-template cannot be empty")
+	}
+	if !hasExtensionPlaceholder(config.FilenameTemplate) {
+		console.Warningf("-filename-template %q has no %%(ext)s placeholder, files may be saved without an extension", config.FilenameTemplate)
+	}
+
+	if config.LoginWait <= 0 {
+		return errors.New("-login-wait must be positive")
+	}
+
+	if config.Timeout <= 0 {
+		return errors.New("-timeout must be positive")
+	}
+
+	if config.DownloadDelay < 0 {
+		return errors.New("-download-delay cannot be negative")
+	}
+
+	if config.MaxRuntime < 0 {
+		return errors.New("-max-runtime cannot be negative")
+	}
+
+	if _, err := newTitleFilter(config.Include, config.Exclude); err != nil {
+		return err
+	}
+
+	if _, err := parsePlatforms(config.Platforms); err != nil {
+		return err
+	}
+
+	if config.NextDataRetries < 0 {
+		return errors.New("-next-data-retries cannot be negative")
+	}
+
+	if config.Tabs < 1 || config.Tabs > maxTabs {
+		return fmt.Errorf("-tabs must be between 1 and %d", maxTabs)
+	}
+
+	if config.SkipOnErrorCount < 0 {
+		return errors.New("-skip-on-error-count cannot be negative")
+	}
+
+	if config.Quiet && config.Verbose {
+		return errors.New("-quiet and -verbose cannot be used together")
+	}
+
+	if config.Quiet && config.Progress {
+		return errors.New("-quiet and -progress cannot be used together")
+	}
+
+	if config.JSON && config.Progress {
+		return errors.New("-json and -progress cannot be used together")
+	}
+
+	if config.PrintURLs && (config.JSON || config.ListOutput) {
+		return errors.New("-print-urls cannot be combined with -json or -list-output")
+	}
+
+	if config.ListFormats && (config.JSON || config.ListOutput || config.PrintURLs) {
+		return errors.New("-list-formats cannot be combined with -json, -list-output or -print-urls")
+	}
+
+	if config.ExportFeed != "" && (config.ListOutput || config.PrintURLs || config.ListFormats) {
+		return errors.New("-export-feed cannot be combined with -list-output, -print-urls or -list-formats")
+	}
+
+	if config.ExportCookies != "" {
+		if _, err := cookieExportFormat(config.ExportCookies); err != nil {
+			return err
+		}
+	}
+
+	usingEmail := config.Email != "" && config.Password != ""
+	usingCookies := config.CookiesFile != "" || config.CookiesFromBrowser != "" || config.AuthToken != ""
+
+	if err := validateCookiesFlags(*config); err != nil {
+		return err
+	}
+
+	if config.CookiesFile != "" && config.CookiesFromBrowser != "" {
+		return errors.New("-cookies and -cookies-from-browser cannot be used together")
+	}
+
+	if paths := splitCookiesPaths(config.CookiesFile); paths != nil {
+		if err := validateCookiesPaths(paths); err != nil {
+			return err
+		}
+	}
+
+	if config.AuthToken != "" && (config.CookiesFile != "" || config.CookiesFromBrowser != "") {
+		return errors.New("-auth-token cannot be combined with -cookies or -cookies-from-browser")
+	}
+
+	if config.CookiesAuthHeader != "" && !isCookiesURL(config.CookiesFile) {
+		return errors.New("-cookies-auth-header requires -cookies to be an http:// or https:// URL")
+	}
+
+	if config.CookiesFromBrowser != "" {
+		if err := validateCookieBrowser(config.CookiesFromBrowser); err != nil {
+			return err
+		}
+	}
+
+	if !usingEmail && !usingCookies && !config.ManualLogin && config.ProfileDir == "" && config.FromHTML == "" {
+		return errors.New("you must provide either cookies file, -auth-token, email+password, -manual-login or -profile-dir for authentication")
+	}
+
+	if config.ManualLogin && (usingEmail || usingCookies) {
+		return errors.New("-manual-login cannot be combined with -email, -cookies or -auth-token")
+	}
+
+	if config.CheckAuth && (usingEmail || config.ManualLogin) {
+		return errors.New("-check-auth checks saved sessions, use it with -cookies, -auth-token or -profile-dir")
+	}
+
+	if config.SaveCookies != "" && !usingEmail && !config.ManualLogin {
+		return errors.New("-save-cookies requires -email and -password, or -manual-login")
+	}
+	return nil
+}
+
+// ExtractVideos scrapes the classroom at config.SkoolURL and returns its
+// videos without downloading them. It only launches the browser, signs in and
+// extracts; config is validated the same way as on the command line, so start
+// from DefaultConfig.
+//
+// Progress is printed to stdout like on the command line, and Human and
+// HumanSeed are not used. Calls share the package's logger and timings, so
+// don't run several at once.
+func ExtractVideos(ctx context.Context, config Config) ([]VideoEntry, error) {
+	if err := validateConfig(&config); err != nil {
+		return nil, err
+	}
+	result, err := scrapeVideos(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	videos, _ := dedupeVideos(result.Videos)
+	if platforms, _ := parsePlatforms(config.Platforms); platforms != nil {
+		videos, _ = filterPlatforms(videos, platforms)
+	}
+	return videos, nil
+}
+
+// scrapeVideos picks the scraper for the configured auth method. Unlike
+// ExtractVideos it also returns the course details used for the output folder.
+func scrapeVideos(ctx context.Context, config Config) (*scrapeResult, error) {
+	// A saved page is read without a browser or signing in
+	if config.FromHTML != "" {
+		return scrapeHTMLFile(config)
+	}
+	if config.ManualLogin {
+		return scrapeWithManualLogin(ctx, config)
+	}
+	// Both launch a fresh browser, so they can start over if it dies
+	if config.Email != "" && config.Password != "" {
+		return withBrowserRelaunch(ctx, func(ctx context.Context) (*scrapeResult, error) {
+			return scrapeWithLogin(ctx, config)
+		})
+	}
+	if config.CookiesFile != "" || config.AuthToken != "" {
+		return withBrowserRelaunch(ctx, func(ctx context.Context) (*scrapeResult, error) {
+			return scrapeWithCookies(ctx, config)
+		})
+	}
+	return scrapeWithProfile(ctx, config)
+}
+
+func getBrowserCandidates() []string {
+	return browserCandidates(runtime.GOOS, os.Getenv)
+}
+
+// browserCandidates returns the browsers to look for on goos, in order of
+// preference, reading install locations from getenv
+func browserCandidates(goos string, getenv func(string) string) []string {
+	switch goos {
+	case "windows":
+		// Browsers are rarely in PATH on Windows, so fall back to Edge's default
+		// installation path (built-in on Windows 10/11) via the PROGRAMFILES env var.
+		programFiles := getenv("PROGRAMFILES")
+		if programFiles == "" {
+			programFiles = `C:\Program Files`
+		}
+		candidates := []string{
+			"msedge",
+			"chrome",
+			"chromium",
+			"vivaldi",
+			"opera",
+			filepath.Join(programFiles, "Microsoft", "Edge", "Application", "msedge.exe"),
+		}
+		// Vivaldi and Opera install per user by default
+		if localAppData := getenv("LOCALAPPDATA"); localAppData != "" {
+			candidates = append(candidates,
+				filepath.Join(localAppData, "Vivaldi", "Application", "vivaldi.exe"),
+				filepath.Join(localAppData, "Programs", "Opera", "opera.exe"),
+			)
+		}
+		return append(candidates,
+			filepath.Join(programFiles, "Vivaldi", "Application", "vivaldi.exe"),
+			filepath.Join(programFiles, "Opera", "opera.exe"),
+		)
+
+	case "darwin":
+		// macOS browsers live in /Applications; they are not typically in PATH.
+		return []string{
+			"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome",
+			"/Applications/Chromium.app/Contents/MacOS/Chromium",
+			"/Applications/Microsoft Edge.app/Contents/MacOS/Microsoft Edge",
+			"/Applications/Brave Browser.app/Contents/MacOS/Brave Browser",
+		}
+
+	default:
+		return []string{
+			"chromium-browser",
+			"chromium",
+			"google-chrome",
+			"google-chrome-stable",
+			"microsoft-edge",
+			"brave-browser",
+			"brave-browser-stable",
+			"brave",
+			"vivaldi",
+			"vivaldi-stable",
+			"opera",
+		}
+	}
+}
+
+// resolveBrowserCandidate returns the path of a browser given as an absolute
+// path or a command in PATH, reporting false if it isn't installed
+func resolveBrowserCandidate(candidate string) (string, bool) {
+	if filepath.IsAbs(candidate) {
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, true
+		}
+		return "", false
+	}
+	if path, err := exec.LookPath(candidate); err == nil {
+		return path, true
+	}
+	return "", false
+}
+
+func findBrowser(customPath string) (string, error) {
+	if customPath != "" {
+		if path, ok := resolveBrowserCandidate(customPath); ok {
+			return path, nil
+		}
+		return "", fmt.Errorf("specified browser not found: %s", customPath)
+	}
+
+	for _, candidate := range getBrowserCandidates() {
+		console.Debug("Checking browser candidate:", candidate)
+		if path, ok := resolveBrowserCandidate(candidate); ok {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf(
+		"no supported browser found.\n" +
+			"Supported: Microsoft Edge (built-in on Windows 10/11), Google Chrome, Chromium, Brave, Vivaldi, Opera.\n" +
+			"Install one of the above, or specify the path with: -browser=/path/to/browser",
+	)
+}
+
+// setupBrowser launches the browser as a child of parent, so cancelling parent
+// closes it
+func setupBrowser(parent context.Context, config Config, timeout time.Duration) (context.Context, context.CancelFunc, error) {
+	resolvedPath, err := findBrowser(config.BrowserPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := launchEngine(config.Engine, resolvedPath); err != nil {
+		return nil, nil, err
+	}
+
+	console.Infof("Using browser: %s", resolvedPath)
+
+	opts, err := browserAllocatorOptions(config, resolvedPath, chromedp.Flag)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// chromedp's internal logging is only useful when debugging, so keep it to verbose mode
+	var ctxOpts []chromedp.ContextOption
+	if console.enabled(levelVerbose) {
+		ctxOpts = append(ctxOpts, chromedp.WithLogf(log.Printf))
+	}
+
+	allocCtx, cancel := chromedp.NewExecAllocator(parent, opts...)
+	ctx, cancel2 := chromedp.NewContext(allocCtx, ctxOpts...)
+	ctx, cancel3 := context.WithTimeout(ctx, timeout)
+	cancelAll := func() {
+		cancel3()
+		cancel2()
+		cancel()
+	}
+
+	// Start the browser now rather than on the first action, so launch failures
+	// surface here and the launch time can be measured on its own
+	stopTimer := timings.Start("browser launch")
+	err = chromedp.Run(ctx)
+	stopTimer()
+	if err != nil {
+		cancelAll()
+		return nil, nil, fmt.Errorf("failed to start browser: %v", err)
+	}
+
+	return ctx, cancelAll, nil
+}
+
+// extractNextDataJSON extracts the __NEXT_DATA__ JSON object from Skool's HTML
+// This contains the complete course structure with all video URLs
+func extractNextDataJSON(html string) (map[string]interface{}, error) {
+	// Find the __NEXT_DATA__ script tag
+	re := regexp.MustCompile(`<script id="__NEXT_DATA__" type="application/json">([\s\S]*?)</script>`)
+	matches := re.FindStringSubmatch(html)
+
+	if len(matches) < 2 {
+		return nil, fmt.Errorf("__NEXT_DATA__ script tag not found in HTML")
+	}
+
+	// Parse JSON
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(matches[1]), &data); err != nil {
+		return nil, fmt.Errorf("failed to parse __NEXT_DATA__ JSON: %w", err)
+	}
+
+	return data, nil
+}
+
+// extractLoomURLsFromNextData recursively walks the course structure in __NEXT_DATA__
+// and extracts all video URLs (Loom and YouTube)
+func extractLoomURLsFromNextData(data map[string]interface{}) []string {
+	return videoURLs(extractVideosFromNextData(data))
+}
+
+// extractVideosFromNextData is extractLoomURLsFromNextData with lesson titles
+func extractVideosFromNextData(data map[string]interface{}) []VideoEntry {
+	result, skipped := walkNextDataVideos(data)
+	reportSkippedLessons(skipped)
+	return result
+}
+
+// courseTree returns the course root from __NEXT_DATA__: data.props.pageProps.course
+func courseTree(data map[string]interface{}) (map[string]interface{}, bool) {
+	props, ok := data["props"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	pageProps, ok := props["pageProps"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	course, ok := pageProps["course"].(map[string]interface{})
+	return course, ok
+}
+
+// walkNextDataVideos returns the videos in the course tree along with the
+// lessons that were skipped
+func walkNextDataVideos(data map[string]interface{}) ([]VideoEntry, skippedLessons) {
+	course, ok := courseTree(data)
+	if !ok {
+		return nil, skippedLessons{}
+	}
+	return walkCourseVideos(course, "", nil)
+}
+
+// walkCourseVideos returns the videos and skipped lessons in the subtree
+// rooted at course, whose own position in the course tree is position
+func walkCourseVideos(course map[string]interface{}, module string, position []int) ([]VideoEntry, skippedLessons) {
+	seen := make(map[string]bool)
+	var result []VideoEntry
+	var skipped skippedLessons
+
+	// Recursive function to walk the course tree, tracking the enclosing set
+	var walkCourseTree func(node map[string]interface{}, module string, position []int)
+	walkCourseTree = func(node map[string]interface{}, module string, position []int) {
+		if node == nil {
+			return
+		}
+
+		// Check if this node has course metadata with a videoLink
+		if courseObj, ok := node["course"].(map[string]interface{}); ok {
+			if unitType, _ := courseObj["unitType"].(string); unitType == courseUnitSet {
+				module = lessonTitle(courseObj)
+			}
+			if metadata, ok := courseObj["metadata"].(map[string]interface{}); ok {
+				lessonStart := len(result)
+				// Text-only lessons and sets carry an empty videoLink
+				if videoLink, ok := metadata["videoLink"].(string); ok && strings.TrimSpace(videoLink) != "" {
+					var link string
+					var platform videoPlatform
+					// Skip lessons whose video hasn't been uploaded yet
+					if isPlaceholderVideoLink(videoLink) {
+						skipped.Pending = append(skipped.Pending, lessonTitle(courseObj))
+					} else if strings.Contains(videoLink, "loom.com") && loomIDRegex.MatchString(videoLink) {
+						// Extract video ID from URL
+						if matches := loomIDRegex.FindStringSubmatch(videoLink); len(matches) >= 3 {
+							// Normalize to share URL format
+							link, platform = fmt.Sprintf("https://www.loom.com/share/%s", matches[2]), platformLoom
+						}
+					} else if normalizedURL := normalizeYouTubeURL(videoLink); normalizedURL != "" {
+						link, platform = normalizedURL, platformYouTube
+					} else if driveURL := normalizeDriveURL(videoLink); driveURL != "" {
+						link, platform = driveURL, platformDrive
+					} else if isDirectVideoURL(videoLink) {
+						link, platform = strings.TrimSpace(videoLink), platformDirect
+					} else {
+						skipped.Unsupported = append(skipped.Unsupported, unsupportedLesson{Title: lessonTitle(courseObj), Link: strings.TrimSpace(videoLink)})
+					}
+
+					if link != "" {
+						video := newVideoEntry(link, platform)
+						video.Title, video.Module, video.Added, video.Thumbnail = lessonTitle(courseObj), module, lessonAdded(courseObj), lessonThumbnail(metadata)
+						video.Position = position
+						if !seen[video.Key()] {
+							seen[video.Key()] = true
+							result = append(result, video)
+						}
+					}
+				}
+
+				// Bonus videos pasted into the lesson text are numbered after
+				// the main one so their files don't collide
+				for _, extra := range lessonContentVideos(metadata) {
+					if seen[extra.Key()] {
+						continue
+					}
+					seen[extra.Key()] = true
+					extra.Title = lessonTitle(courseObj)
+					if n := len(result) - lessonStart + 1; n > 1 {
+						extra.Title = fmt.Sprintf("%s (%d)", extra.Title, n)
+					}
+					extra.Module, extra.Added, extra.Position = module, lessonAdded(courseObj), position
+					result = append(result, extra)
+				}
+			}
+		}
+
+		// Recursively process children (sets and modules)
+		if children, ok := node["children"].([]interface{}); ok {
+			for i, child := range children {
+				if childMap, ok := child.(map[string]interface{}); ok {
+					walkCourseTree(childMap, module, childPosition(position, i))
+				}
+			}
+		}
+	}
+
+	// Start walking from the course root
+	walkCourseTree(course, module, position)
+
+	return result, skipped
+}
+
+// unsupportedLesson is a lesson whose videoLink isn't on a supported host
+type unsupportedLesson struct {
+	Title string
+	Link  string
+}
+
+// skippedLessons are the lessons a course walk found but can't download
+type skippedLessons struct {
+	Pending     []string            // titles of lessons whose video isn't uploaded yet
+	Unsupported []unsupportedLesson // lessons whose videoLink isn't a supported video
+}
+
+// add merges other into s, leaving out lessons s already has
+func (s *skippedLessons) add(other skippedLessons) {
+	for _, title := range other.Pending {
+		if !slices.Contains(s.Pending, title) {
+			s.Pending = append(s.Pending, title)
+		}
+	}
+	for _, lesson := range other.Unsupported {
+		if !slices.Contains(s.Unsupported, lesson) {
+			s.Unsupported = append(s.Unsupported, lesson)
+		}
+	}
+}
+
+// lessonContentKeys are the lesson metadata fields holding its text, which
+// can embed videos besides the main videoLink
+var lessonContentKeys = []string{"desc", "description", "content", "body"}
+
+// lessonContentVideos returns the videos linked from a lesson's text
+func lessonContentVideos(metadata map[string]interface{}) []VideoEntry {
+	var videos []VideoEntry
+	for _, key := range lessonContentKeys {
+		if text, ok := metadata[key].(string); ok && text != "" {
+			// Rich text is sometimes stored as escaped JSON
+			videos = append(videos, findVideoLinks(strings.ReplaceAll(text, `\/`, "/"))...)
+		}
+	}
+	return videos
+}
+
+func reportSkippedLessons(skipped skippedLessons) {
+	if len(skipped.Pending) > 0 {
+		console.Warningf("Skipped %d lesson(s) whose video is not available yet:", len(skipped.Pending))
+		for _, title := range skipped.Pending {
+			console.Warning("  -", title)
+		}
+	}
+	if len(skipped.Unsupported) > 0 {
+		console.Warningf("Skipped %d lesson(s) whose video link isn't supported:", len(skipped.Unsupported))
+		for _, lesson := range skipped.Unsupported {
+			console.Warningf("  - %s: %s", lesson.Title, lesson.Link)
+		}
+	}
+}
+
+// placeholderVideoLinks are values seen in videoLink before the real video is uploaded
+var placeholderVideoLinks = map[string]bool{
+	"#":           true,
+	"about:blank": true,
+	"coming soon": true,
+	"tbd":         true,
+	"null":        true,
+	"undefined":   true,
+}
+
+// loomIDRegex extracts the video ID from a Loom share or embed link
+var loomIDRegex = regexp.MustCompile(`loom\.com/(share|embed)/([a-zA-Z0-9_-]+)`)
+
+// placeholderVideoLinkRegex matches Loom/YouTube links that are missing the video ID
+var placeholderVideoLinkRegex = regexp.MustCompile(`^https?://(?:www\.)?(?:loom\.com/(?:share|embed)|youtube\.com/(?:watch\?v=|embed)|youtu\.be)/?$`)
+
+// isPlaceholderVideoLink reports whether a lesson's videoLink is a known
+// "coming soon" placeholder rather than a real video. An empty link is not a
+// placeholder: lessons without a video leave it empty
+func isPlaceholderVideoLink(videoLink string) bool {
+	trimmed := strings.ToLower(strings.TrimSpace(videoLink))
+	if trimmed == "" {
+		return false
+	}
+	if placeholderVideoLinks[trimmed] {
+		return true
+	}
+	return placeholderVideoLinkRegex.MatchString(trimmed)
+}
+
+// untitledLesson is shown for lessons without a title or name
+const untitledLesson = "(untitled lesson)"
+
+// lessonTitle returns a human-readable title for a course node
+func lessonTitle(courseObj map[string]interface{}) string {
+	if metadata, ok := courseObj["metadata"].(map[string]interface{}); ok {
+		if title, ok := metadata["title"].(string); ok && title != "" {
+			return title
+		}
+	}
+	if name, ok := courseObj["name"].(string); ok && name != "" {
+		return name
+	}
+	return untitledLesson
+}
+
+// normalizeYouTubeURL extracts video ID and normalizes YouTube URL to standard watch format
+func normalizeYouTubeURL(videoLink string) string {
+	// Regex patterns for different YouTube URL formats
+	patterns := []string{
+		`(?:youtube\.com/watch\?v=|youtu\.be/|youtube\.com/embed/|youtube\.com/v/)([a-zA-Z0-9_-]{11})`,
+	}
+
+	for _, pattern := range patterns {
+		re := regexp.MustCompile(pattern)
+		if matches := re.FindStringSubmatch(videoLink); len(matches) >= 2 {
+			videoID := matches[1]
+			return fmt.Sprintf("https://www.youtube.com/watch?v=%s", videoID)
+		}
+	}
+
+	return ""
+}
+
+// extractLoomURLs extracts video URLs (Loom and YouTube) from HTML and says
+// where it found them. A course tree without videos is reported as
+// sourceNextData with no URLs, a page without one as sourceNone.
+// NEW APPROACH: Try __NEXT_DATA__ JSON first (fast, accurate), fallback to regex (old method)
+func extractLoomURLs(html string) ([]string, extractionSource) {
+	videos, source := extractVideos(html)
+	return videoURLs(videos), source
+}
+
+// extractVideos is extractLoomURLs with lesson titles, which are only known
+// when the videos come from __NEXT_DATA__
+func extractVideos(html string) ([]VideoEntry, extractionSource) {
+	// Try extracting from __NEXT_DATA__ JSON first
+	haveCourse := false
+	if nextData, err := extractNextDataJSON(html); err == nil {
+		_, haveCourse = courseTree(nextData)
+		videos := extractVideosFromNextData(nextData)
+		if len(videos) > 0 {
+			console.Infof("Extracted %d video(s) from __NEXT_DATA__ JSON", len(videos))
+			return videos, sourceNextData
+		}
+		console.Warning("No videos found in __NEXT_DATA__, falling back to regex extraction")
+	} else {
+		console.Warningf("__NEXT_DATA__ extraction failed (%v), falling back to regex extraction", err)
+	}
+
+	// Fallback to old regex-based extraction
+	result := findVideoLinks(html)
+
+	if len(result) == 0 {
+		// The course tree was read fine, it just has no videos
+		if haveCourse {
+			return nil, sourceNextData
+		}
+		return nil, sourceNone
+	}
+	console.Infof("Extracted %d video(s) from regex patterns", len(result))
+	return result, sourceRegex
+}
+
+// findVideoLinks returns the videos linked anywhere in text, without titles,
+// leaving out duplicates and Loom links that aren't a single video
+func findVideoLinks(text string) []VideoEntry {
+	// Loom patterns, capturing any deeper path so folder pages can be told apart from videos
+	loomShareRegex := regexp.MustCompile(`https?://(?:www\.)?loom\.com/share/([a-zA-Z0-9]+)(/[a-zA-Z0-9_-]+)?`)
+	loomEmbedRegex := regexp.MustCompile(`https?://(?:www\.)?loom\.com/embed/([a-zA-Z0-9]+)`)
+
+	// YouTube patterns
+	youtubeRegex := regexp.MustCompile(`https?://(?:www\.)?(?:youtube\.com/watch\?v=|youtu\.be/|youtube\.com/embed/|youtube\.com/v/)([a-zA-Z0-9_-]{11})`)
+
+	// Links to video files, checked against directVideoExtensions below
+	directLinkRegex := regexp.MustCompile(`https?://[^\s"'<>]+\.(?i:mp4|m4v|mov|webm|mkv)(?:\?[^\s"'<>]*)?`)
+
+	var matches []VideoEntry
+
+	// Extract Loom share URLs
+	for _, match := range loomShareRegex.FindAllStringSubmatch(text, -1) {
+		if match[2] != "" {
+			console.Debugf("Skipping Loom link that isn't a video: %s", match[0])
+			continue
+		}
+		matches = append(matches, newVideoEntry(match[0], platformLoom))
+	}
+
+	// Convert Loom embed URLs to share URLs
+	loomEmbedMatches := loomEmbedRegex.FindAllStringSubmatch(text, -1)
+	for _, match := range loomEmbedMatches {
+		if len(match) >= 2 {
+			shareURL := fmt.Sprintf("https://www.loom.com/share/%s", match[1])
+			matches = append(matches, newVideoEntry(shareURL, platformLoom))
+		}
+	}
+
+	// Extract and normalize YouTube URLs
+	youtubeMatches := youtubeRegex.FindAllStringSubmatch(text, -1)
+	for _, match := range youtubeMatches {
+		if len(match) >= 2 {
+			videoID := match[1]
+			watchURL := fmt.Sprintf("https://www.youtube.com/watch?v=%s", videoID)
+			matches = append(matches, newVideoEntry(watchURL, platformYouTube))
+		}
+	}
+
+	// Extract and normalize Google Drive file links
+	for _, link := range driveFileRegex.FindAllString(text, -1) {
+		matches = append(matches, newVideoEntry(normalizeDriveURL(link), platformDrive))
+	}
+
+	// Extract direct links to video files
+	for _, link := range directLinkRegex.FindAllString(text, -1) {
+		if isDirectVideoURL(link) {
+			matches = append(matches, newVideoEntry(link, platformDirect))
+		}
+	}
+
+	// Remove anything that isn't a single Loom video, then duplicates
+	var result []VideoEntry
+	for _, video := range matches {
+		if video.Platform == platformLoom && !isLoomVideoURL(video.URL) {
+			console.Debugf("Skipping Loom link that isn't a video: %s", video.URL)
+			continue
+		}
+		result = append(result, video)
+	}
+	result, _ = dedupeVideos(result)
+	return result
+}
+
+// loomVideoRegex matches a single Loom video in the loom.com/share/<id> form
+var loomVideoRegex = regexp.MustCompile(`^https?://(?:www\.)?loom\.com/share/([a-zA-Z0-9]+)$`)
+
+// loomNonVideoPaths are loom.com/share/ paths that lead to pages other than videos
+var loomNonVideoPaths = map[string]bool{
+	"folder":  true,
+	"folders": true,
+	"profile": true,
+	"spaces":  true,
+}
+
+// isLoomVideoURL reports whether url points at a single Loom video
+func isLoomVideoURL(url string) bool {
+	match := loomVideoRegex.FindStringSubmatch(url)
+	return match != nil && !loomNonVideoPaths[strings.ToLower(match[1])]
+}
+
+func scrapeWithLogin(parent context.Context, config Config) (*scrapeResult, error) {
+	ctx, cancel, err := setupBrowser(parent, config, config.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	if profileHasSession(ctx, config) {
+		return navigateAndScrape(ctx, config)
+	}
+
+	console.Auth("Attempting login with email and password...")
+	stopTimer := timings.Start("authentication")
+
+	err = submitLoginForm(ctx, config)
+	if errors.Is(err, errLoginNavigation) {
+		console.Warningf("%v, retrying the login once...", err)
+		err = submitLoginForm(ctx, config)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	loginSuccess, err := waitForLoginResult(ctx, config.LoginWait)
+	if err != nil {
+		return nil, err
+	}
+
+	// A captcha after submitting the form blocks the redirect even with valid credentials
+	if !loginSuccess {
+		solved, err := handleChallenge(ctx, config.Headless)
+		if err != nil {
+			return nil, err
+		}
+		if solved {
+			if loginSuccess, err = waitForLoginResult(ctx, config.LoginWait); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Accounts with two-factor authentication get a code prompt before the redirect
+	if !loginSuccess {
+		submitted, err := completeTwoFactor(ctx, config.TOTPSecret)
+		if err != nil {
+			return nil, err
+		}
+		if submitted {
+			if loginSuccess, err = waitForLoginResult(ctx, config.LoginWait); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	stopTimer()
+
+	if !loginSuccess {
+		return nil, fmt.Errorf("login failed: still on the login page after %s, check your credentials or rerun with -headless=false to watch the login", config.LoginWait)
+	}
+
+	var currentURL string
+	if err := chromedp.Run(ctx, chromedp.Location(&currentURL)); err != nil {
+		return nil, fmt.Errorf("login process failed: %v", err)
+	}
+	console.Success("Login successful! Redirected to:", currentURL)
+
+	// Save the session right away so it survives even if scraping fails later
+	if config.SaveCookies != "" {
+		if count, err := saveBrowserCookies(ctx, config.SaveCookies); err != nil {
+			console.Warningf("Failed to save cookies: %v", err)
+		} else {
+			console.Authf("Saved %d cookie(s) to %s, reuse them with -cookies=%s", count, config.SaveCookies, config.SaveCookies)
+		}
+	}
+	return navigateAndScrape(ctx, config)
+}
+
+// submitLoginForm opens the login form and submits the email and password.
+// Pages that fail to load return errLoginNavigation, elements that never
+// appear return a selectorTimeoutError.
+func submitLoginForm(ctx context.Context, config Config) error {
+	var currentURL string
+
+	// Navigate to the main Skool site
+	if err := chromedp.Run(ctx, chromedp.Tasks{
+		chromedp.Navigate(skoolBaseURL),
+		human.Pause(),
+		chromedp.Location(&currentURL),
+	}); err != nil {
+		return fmt.Errorf("failed to navigate to Skool: %w: %v", errLoginNavigation, err)
+	}
+
+	console.Info("Landed on:", currentURL)
+
+	// Cloudflare may show an interstitial before the site itself
+	if _, err := handleChallenge(ctx, config.Headless); err != nil {
+		return err
+	}
+
+	// Try to find and click the login button
+	loginButton, err := waitForLoginElement(ctx, loginButtonElement, config.LoginWait)
+	if err == nil {
+		err = chromedp.Run(ctx, human.Pause(), chromedp.Click(loginButton, chromedp.BySearch))
+	}
+
+	// If login button not found, navigate directly to login page
+	if err != nil {
+		console.Warningf("Couldn't use the login button (%v), trying direct navigation to login page...", err)
+		if err := chromedp.Run(ctx, chromedp.Navigate(skoolLoginURL)); err != nil {
+			return fmt.Errorf("couldn't access login page: %w: %v", errLoginNavigation, err)
+		}
+	}
+
+	emailInput, err := waitForLoginElement(ctx, emailInputElement, config.LoginWait)
+	if err != nil {
+		return err
+	}
+	if err := chromedp.Run(ctx, chromedp.Location(&currentURL)); err == nil {
+		console.Info("Login page:", currentURL)
+	}
+
+	// Complete the login form
+	if err := chromedp.Run(ctx, chromedp.SendKeys(emailInput, config.Email, chromedp.BySearch)); err != nil {
+		return fmt.Errorf("login process failed: %v", err)
+	}
+	passwordInput, err := waitForLoginElement(ctx, passwordInputElement, config.LoginWait)
+	if err != nil {
+		return err
+	}
+	if err := chromedp.Run(ctx, human.Pause(), chromedp.SendKeys(passwordInput, config.Password, chromedp.BySearch)); err != nil {
+		return fmt.Errorf("login process failed: %v", err)
+	}
+	submitButton, err := waitForLoginElement(ctx, submitButtonElement, config.LoginWait)
+	if err != nil {
+		return err
+	}
+	if err := chromedp.Run(ctx, human.Pause(), chromedp.Click(submitButton, chromedp.BySearch)); err != nil {
+		return fmt.Errorf("login process failed: %v", err)
+	}
+	return nil
+}
+
+func scrapeWithCookies(parent context.Context, config Config) (*scrapeResult, error) {
+	// Load and check cookies before spending time on the browser
+	cookies, err := loadCookies(config)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing cookies: %v", err)
+	}
+	if err := validateCookies(cookies); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel, err := setupBrowser(parent, config, config.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	if profileHasSession(ctx, config) {
+		return navigateAndScrape(ctx, config)
+	}
+
+	// Log cookie info
+	console.Auth("Setting cookies...")
+	stopTimer := timings.Start("authentication")
+	for _, c := range cookies {
+		if c.Name == skoolAuthCookie && isSkoolHost(c.Domain) {
+			truncatedValue := c.Value
+			if len(truncatedValue) > 20 {
+				truncatedValue = truncatedValue[:20] + "..."
+			}
+			console.Authf("Auth token found: %s", truncatedValue)
+		}
+	}
+
+	// Enable network and set cookies
+	if err := chromedp.Run(ctx, network.Enable()); err != nil {
+		return nil, err
+	}
+
+	if err := chromedp.Run(ctx, network.SetCookies(cookies)); err != nil {
+		return nil, fmt.Errorf("error setting cookies: %v", err)
+	}
+
+	var currentURL string
+	// Set headers and navigate first to main site, then to target URL
+	err = chromedp.Run(ctx, chromedp.Tasks{
+		network.SetExtraHTTPHeaders(network.Headers{
+			"Referer":         skoolBaseURL,
+			"Accept":          "text/html,application/xhtml+xml,application/xml",
+			"Accept-Language": "en-US,en;q=0.9",
+			"Connection":      "keep-alive",
+		}),
+		chromedp.Navigate(skoolBaseURL),
+		waitReady(pageReadyJS, initialWaitTime),
+		chromedp.Location(&currentURL),
+	})
+
+	stopTimer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to navigate to main site: %v", err)
+	}
+
+	console.Infof("Initial navigation landed on: %s", currentURL)
+	return navigateAndScrape(ctx, config)
+}
+
+// scrapeResult is what a scrape of a classroom found
+type scrapeResult struct {
+	Videos []VideoEntry
+	Course courseMetadata
+	Source extractionSource
+}
+
+// navigateAndScrape scrapes the classroom in an authenticated browser. When
+// that fails or finds no videos, it checks whether the account lacks access
+// and saves the debug capture.
+func navigateAndScrape(ctx context.Context, config Config) (*scrapeResult, error) {
+	result, err := scrapeClassroom(ctx, config)
+	if err == nil && config.IncludeFeed {
+		// The feed is extra, so failing to read it doesn't lose the classroom
+		if videos, feedErr := scrapeFeed(ctx, config); feedErr != nil {
+			console.Warningf("Skipping the community feed: %v", feedErr)
+		} else {
+			result.Videos = append(result.Videos, videos...)
+		}
+	}
+	if err != nil || len(result.Videos) == 0 {
+		saveDebugCapture(ctx, config)
+		if paywallErr := checkPaywall(ctx); paywallErr != nil {
+			return nil, paywallErr
+		}
+		return result, err
+	}
+	if config.CaptureNetwork {
+		captureMediaURLs(ctx, config, result.Videos)
+	}
+	return result, nil
+}
+
+func scrapeClassroom(ctx context.Context, config Config) (*scrapeResult, error) {
+	var currentURL string
+	targetURL, waitTime := config.SkoolURL, config.WaitTime
+
+	console.Info("Navigating to classroom:", targetURL)
+	if parsed, err := parseSkoolURL(targetURL); err == nil {
+		console.Debugf("Community: %s, course: %s", parsed.Community, parsed.Course)
+	}
+	stopTimer := timings.Start("classroom navigation")
+	err := chromedp.Run(ctx, chromedp.Tasks{
+		chromedp.Navigate(targetURL),
+		waitReady(pageReadyJS, time.Duration(waitTime)*time.Second),
+		chromedp.Location(&currentURL),
+		human.Interact(),
+	})
+	stopTimer()
+	if err != nil {
+		return nil, fmt.Errorf("failed to navigate to classroom: %v", err)
+	}
+
+	console.Info("Landed on:", currentURL)
+
+	// Check if we're on the right page
+	if strings.Contains(currentURL, "/about") {
+		return nil, fmt.Errorf("authentication succeeded but redirected to public page, check URL permissions")
+	}
+
+	// Get page content, giving the page a few chances to hydrate __NEXT_DATA__
+	stopTimer = timings.Start("extraction")
+	html, err := waitForNextData(func() (string, error) {
+		var html string
+		err := chromedp.Run(ctx, chromedp.OuterHTML("html", &html))
+		return html, err
+	}, config.NextDataRetries, nextDataRetryDelay)
+	if err != nil {
+		return nil, err
+	}
+	if config.CacheHTML != "" {
+		saveHTMLCache(config.CacheHTML, html)
+	}
+
+	result := &scrapeResult{Course: extractCourseMetadata(nil, config.SkoolURL)}
+	nextData, nextDataErr := extractNextDataJSON(html)
+	if nextDataErr == nil {
+		result.Course = extractCourseMetadata(nextData, config.SkoolURL)
+	}
+
+	// A lesson URL (?md=) only needs that lesson's video, not the whole course
+	if parsed, err := parseSkoolURL(config.SkoolURL); err == nil && parsed.Lesson != "" && nextDataErr == nil {
+		if videos, skipped, ok := lessonVideos(nextData, parsed.Lesson); ok {
+			stopTimer()
+			console.Infof("Extracted %d video(s) from lesson %s", len(videos), parsed.Lesson)
+			if len(videos) == 0 && len(skipped.Pending) == 0 && len(skipped.Unsupported) == 0 {
+				console.Warningf("Lesson %s has no videos in the course data; if it's a module, its lessons may not have loaded yet, try a higher -wait", parsed.Lesson)
+			}
+			reportSkippedLessons(skipped)
+			result.Videos = videos
+			result.Source = sourceNextData
+			exportCookiesAfterScrape(ctx, config)
+			return result, nil
+		}
+		console.Warningf("Lesson %s not found in the course data, scraping the whole classroom", parsed.Lesson)
+	}
+
+	// Extract and return video URLs
+	videos, source := extractVideos(html)
+	result.Source = source
+	stopTimer()
+
+	// Large classrooms only include the expanded module in __NEXT_DATA__
+	if nextDataErr == nil {
+		if config.Deep {
+			videos = deepScrape(ctx, config, nextData, videos)
+		} else if _, collapsed := courseNodeIDs(nextData); collapsed > 0 {
+			console.Warningf("%d module(s) appear collapsed and may hide lessons; rerun with -deep to visit each lesson", collapsed)
+		}
+	}
+	if len(videos) == 0 {
+		console.Warning("No videos found on the page.")
+	}
+
+	result.Videos = videos
+	exportCookiesAfterScrape(ctx, config)
+	return result, nil
+}
+
+// exportCookiesAfterScrape writes the -export-cookies file if requested. A
+// failed export is only a warning, so it never fails the scrape.
+func exportCookiesAfterScrape(ctx context.Context, config Config) {
+	if config.ExportCookies == "" {
+		return
+	}
+	if err := exportBrowserCookies(ctx, config.ExportCookies); err != nil {
+		console.Warningf("Failed to export cookies: %v", err)
+	} else {
+		console.Auth("Exported browser cookies to:", config.ExportCookies)
+	}
+}
+
+// waitForNextData fetches the page HTML, retrying up to retries times with a
+// delay while the __NEXT_DATA__ script tag is missing (the SPA hasn't hydrated
+// yet). The last HTML is returned either way so the regex fallback can run.
+func waitForNextData(fetch func() (string, error), retries int, delay time.Duration) (string, error) {
+	for attempt := 0; ; attempt++ {
+		html, err := fetch()
+		if err != nil {
+			return "", err
+		}
+		if _, err := extractNextDataJSON(html); err == nil || attempt >= retries {
+			return html, nil
+		}
+
+		console.Debugf("__NEXT_DATA__ not found yet, retrying in %s (%d/%d)", delay, attempt+1, retries)
+		time.Sleep(delay)
+	}
+}
+
+// Cookie parsing functions
+
+// parseCookiesFile parses a local cookies file, or fetches and parses the
+// cookies at an http(s) URL
+func parseCookiesFile(filePath string) ([]*network.CookieParam, error) {
+	var content []byte
+	var err error
+	if isCookiesURL(filePath) {
+		content, err = fetchCookies(filePath, "")
+	} else {
+		content, err = os.ReadFile(filePath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseCookiesContent(filePath, content, cookiesFormatAuto, sameSiteZeroUnset)
+}
+
+// loadCookies reads the configured cookies file, decrypting it in memory when
+// a cookies password is set. With -auth-token it returns just that cookie.
+func loadCookies(config Config) ([]*network.CookieParam, error) {
+	if config.AuthToken != "" {
+		return []*network.CookieParam{cookieFromAuthToken(config.AuthToken)}, nil
+	}
+	content, err := readCookiesContent(config.CookiesFile, config.CookiesPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	// The extension of an encrypted file says nothing about the format inside
+	name := config.CookiesFile
+	if config.CookiesPassword != "" {
+		name = ""
+	}
+	return parseCookiesContent(name, content, config.CookiesFormat, config.SameSiteZero)
+}
+
+// readCookiesSource reads the -cookies source for the utility modes that run
+// before cookies are staged: a file, stdin for -cookies=- or an http(s) URL.
+// It returns the content, decrypted when a password is set, and the name to
+// detect its format from.
+func readCookiesSource(config Config, stdin io.Reader) ([]byte, string, error) {
+	var content []byte
+	var err error
+	name := config.CookiesFile
+	switch {
+	case config.CookiesFile == stdinCookiesPath:
+		// Piped cookies have no name, their format is sniffed
+		content, err = io.ReadAll(stdin)
+		name = ""
+	case isCookiesURL(config.CookiesFile):
+		content, err = fetchCookies(config.CookiesFile, config.CookiesAuthHeader)
+	default:
+		content, err = os.ReadFile(config.CookiesFile)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	content, err = decodeCookiesContent(content, config.CookiesPassword)
+	if err != nil {
+		return nil, "", err
+	}
+	// The extension of an encrypted file says nothing about the format inside
+	if config.CookiesPassword != "" {
+		name = ""
+	}
+	return content, name, nil
+}
+
+// parseCookiesContent parses cookies in the given format, or in the format
+// detected by detectCookiesFormat for auto. sameSiteZero is passed on to
+// parseJSONCookies.
+func parseCookiesContent(filePath string, content []byte, format, sameSiteZero string) ([]*network.CookieParam, error) {
+	content = stripBOM(content)
+	if detectCookiesFormat(filePath, content, format) == cookiesFormatJSON {
+		return parseJSONCookies(content, sameSiteZero)
+	}
+	return parseNetscapeCookies(content)
+}
+
+// looksLikeJSONCookies sniffs the content for a JSON cookie array
+func looksLikeJSONCookies(content []byte) bool {
+	trimmed := strings.TrimSpace(string(stripBOM(content)))
+	return strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]")
+}
+
+// stageStdinCookies reads cookies piped via stdin into a temp file whose
+// extension reflects the detected format, so the regular file-based parsing
+// and the yt-dlp conversion both work unchanged
+func stageStdinCookies(r io.Reader) (string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	if len(strings.TrimSpace(string(content))) == 0 {
+		return "", fmt.Errorf("no cookies received on stdin")
+	}
+
+	pattern := "cookies-stdin-*.txt"
+	if looksLikeJSONCookies(content) {
+		pattern = "cookies-stdin-*.json"
+	}
+
+	return writeTempCookiesFile(content, pattern)
+}
+
+// writeTempCookiesFile writes content to a new temp file matching pattern
+func writeTempCookiesFile(content []byte, pattern string) (string, error) {
+	tmpFile, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = tmpFile.Close()
+	}()
+
+	if _, err := tmpFile.Write(content); err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return "", err
+	}
+
+	return tmpFile.Name(), nil
+}
+
+// parseJSONCookies parses a JSON cookie array, reading a sameSite of 0 as
+// described by sameSiteZero (see jsonSameSite)
+func parseJSONCookies(content []byte, sameSiteZero string) ([]*network.CookieParam, error) {
+	var jsonCookies []JSONCookie
+	if err := json.Unmarshal(content, &jsonCookies); err != nil {
+		return nil, fmt.Errorf("error parsing JSON cookies: %v", err)
+	}
+
+	var cookies []*network.CookieParam
+	for _, c := range jsonCookies {
+		// Keep a leading dot, CDP uses it to send the cookie to subdomains too
+		cookie := &network.CookieParam{
+			Domain:   c.Host,
+			Name:     c.Name,
+			Value:    c.Value,
+			Path:     c.Path,
+			Secure:   c.IsSecure == 1,
+			HTTPOnly: c.IsHttpOnly == 1,
+			SameSite: jsonSameSite(c.SameSite, sameSiteZero),
+		}
+
+		// Add expiry if present
+		if c.Expiry > 0 {
+			t := cdp.TimeSinceEpoch(time.Unix(c.Expiry, 0))
+			cookie.Expires = &t
+		}
+
+		cookies = append(cookies, cookie)
+	}
+
+	return cookies, nil
+}
+
+// netscapeHTTPOnlyPrefix marks HttpOnly cookies in Netscape cookie files, as
+// written by curl, yt-dlp and most browser extensions
+const netscapeHTTPOnlyPrefix = "#HttpOnly_"
+
+// sessionCookieLifetime is how far ahead session cookies (expiry 0) are dated
+// when written for yt-dlp, which can drop a 0 expiry as expired. The file is
+// only kept for a single download, so the exact value doesn't matter.
+const sessionCookieLifetime = 365 * 24 * time.Hour
+
+// netscapeExpiry returns the expiry to write for a cookie, dating session
+// cookies sessionCookieLifetime after now
+func netscapeExpiry(expiry int64, now time.Time) int64 {
+	if expiry > 0 {
+		return expiry
+	}
+	return now.Add(sessionCookieLifetime).Unix()
+}
+
+func parseNetscapeCookies(content []byte) ([]*network.CookieParam, error) {
+	lines := strings.Split(string(content), "\n")
+	var cookies []*network.CookieParam
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+
+		// HttpOnly cookies are written as comments with a #HttpOnly_ domain prefix
+		httpOnly := strings.HasPrefix(line, netscapeHTTPOnlyPrefix)
+		if httpOnly {
+			line = strings.TrimPrefix(line, netscapeHTTPOnlyPrefix)
+		}
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) < 7 {
+			continue
+		}
+
+		// Keep a leading dot, CDP uses it to send the cookie to subdomains too
+		cookie := &network.CookieParam{
+			Domain:   fields[0],
+			Path:     fields[2],
+			Secure:   fields[3] == "TRUE",
+			Name:     fields[5],
+			Value:    fields[6],
+			HTTPOnly: httpOnly,
+		}
+
+		// Try to parse expiry if present
+		if len(fields) > 4 {
+			expiryStr := fields[4]
+			if expiryStr != "" && expiryStr != "0" {
+				expiry, err := parseInt64(expiryStr)
+				if err == nil && expiry > 0 {
+					t := cdp.TimeSinceEpoch(time.Unix(expiry, 0))
+					cookie.Expires = &t
+				}
+			}
+		}
+
+		cookies = append(cookies, cookie)
+	}
+
+	return cookies, nil
+}
+
+func parseInt64(s string) (int64, error) {
+	var result int64
+	_, err := fmt.Sscanf(s, "%d", &result)
+	return result, err
+}
+
+// resolveYtDlp locates the yt-dlp executable, accepting either an absolute path
+// or a command name that is looked up in PATH
+func resolveYtDlp(ytDlpPath string) (string, error) {
+	if ytDlpPath == "" {
+		ytDlpPath = defaultYtDlpPath
+	}
+
+	if filepath.IsAbs(ytDlpPath) {
+		if _, err := os.Stat(ytDlpPath); err == nil {
+			return ytDlpPath, nil
+		}
+	} else {
+		if path, err := exec.LookPath(ytDlpPath); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf(
+		"yt-dlp not found: %s\n"+
+			"Install it from https://github.com/yt-dlp/yt-dlp#installation, or specify the path with: -ytdlp=/path/to/yt-dlp",
+		ytDlpPath,
+	)
+}
+
+// errTooLarge is returned when yt-dlp skipped a video over -max-filesize
+var errTooLarge = errors.New("skipped, larger than -max-filesize")
+
+// downloadWithYtDlp downloads a single video and returns the path it was saved
+// to, killing yt-dlp if ctx is done first. With a progress bar, yt-dlp's progress lines are rendered on the bar
+// instead of being printed as-is.
+func downloadWithYtDlp(ctx context.Context, video VideoEntry, config Config, bar *progressBar) (string, error) {
+	cookiesFile, cleanup, err := prepareYtDlpCookies(config)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	// yt-dlp appends the final path here once the download has been moved into place
+	pathFile, err := os.CreateTemp("", "skool-downloader-path-*.txt")
+	if err != nil {
+		return "", err
+	}
+	_ = pathFile.Close()
+	defer func() {
+		_ = os.Remove(pathFile.Name())
+	}()
+
+	args := buildYtDlpArgs(video, cookiesFile, pathFile.Name(), config)
+
+	console.Debug("Running:", config.YtDlpPath, strings.Join(args, " "))
+	cmd := exec.CommandContext(ctx, config.YtDlpPath, args...)
+	// Pass errors through as they happen, but keep the last one for the
+	// report, and watch both streams for a -max-filesize skip
+	tail := &stderrTail{}
+	tooLarge := newOutputWatch(tooLargeMessage)
+	cmd.Stderr = io.MultiWriter(os.Stderr, tail, tooLarge)
+	switch {
+	case config.JSON:
+		// Keep stdout for JSON events
+		cmd.Stdout = io.MultiWriter(os.Stderr, tooLarge)
+		err = cmd.Run()
+	case bar == nil:
+		cmd.Stdout = io.MultiWriter(os.Stdout, tooLarge)
+		err = cmd.Run()
+	default:
+		err = runWithProgress(cmd, io.MultiWriter(os.Stdout, tooLarge), bar)
+	}
+	if err != nil {
+		if line := tail.Line(); line != "" {
+			return "", fmt.Errorf("yt-dlp failed: %v: %s", err, line)
+		}
+		return "", fmt.Errorf("yt-dlp failed: %v", err)
+	}
+
+	path := readDownloadedPath(pathFile.Name())
+	// yt-dlp exits successfully without saving anything when a video is over
+	// --max-filesize. An archive or match filter in -ytdlp-args can also leave
+	// no path, so only its own message counts.
+	if path == "" && tooLarge.Seen() {
+		return "", fmt.Errorf("%w %s", errTooLarge, config.MaxFilesize)
+	}
+	return path, nil
+}
+
+// runWithProgress runs cmd, rendering its progress lines on bar and passing
+// the rest of its output to out
+func runWithProgress(cmd *exec.Cmd, out io.Writer, bar *progressBar) error {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	if err := copyProgress(stdout, out, bar); err != nil {
+		// Keep yt-dlp from blocking on a full pipe
+		_, _ = io.Copy(out, stdout)
+	}
+	return cmd.Wait()
+}
+
+// readDownloadedPath returns the last path yt-dlp wrote with --print-to-file,
+// or "" if it didn't write one (e.g. the file already existed)
+func readDownloadedPath(pathFile string) string {
+	content, err := os.ReadFile(pathFile)
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// prepareYtDlpCookies returns a Netscape cookies file for yt-dlp, converting
+// JSON cookies and decrypting encrypted ones into a temp file that the
+// returned cleanup function removes
+func prepareYtDlpCookies(config Config) (string, func(), error) {
+	noop := func() {}
+	cookiesFile := config.CookiesFile
+
+	// Only pass cookies if a cookies file is provided
+	if cookiesFile == "" {
+		return "", noop, nil
+	}
+
+	content, err := readCookiesContent(cookiesFile, config.CookiesPassword)
+	if err != nil {
+		return "", noop, fmt.Errorf("error reading cookies: %v", err)
+	}
+
+	// The extension of an encrypted file says nothing about the format inside
+	name := cookiesFile
+	if config.CookiesPassword != "" {
+		name = ""
+	}
+
+	var tmpFile string
+	if detectCookiesFormat(name, stripBOM(content), config.CookiesFormat) == cookiesFormatJSON {
+		tmpFile, err = writeNetscapeCookiesFromJSON(stripBOM(content))
+	} else if config.CookiesPassword == "" && !bytes.HasPrefix(content, utf8BOM) {
+		// A plain Netscape file can be handed to yt-dlp as-is
+		return cookiesFile, noop, nil
+	} else {
+		tmpFile, err = writeTempCookiesFile(stripBOM(content), "cookies-*.txt")
+	}
+	if err != nil {
+		return "", noop, fmt.Errorf("error converting JSON cookies: %v", err)
+	}
+
+	return tmpFile, func() {
+		removeTempFile(tmpFile, config.KeepTemp)
+	}, nil
+}
+
+// removeTempFile deletes a temporary file, or with -keep-temp leaves it in
+// place and prints its path
+func removeTempFile(path string, keep bool) {
+	if keep {
+		console.Info("Kept temporary file:", path)
+		return
+	}
+	_ = os.Remove(path)
+}
+
+// recodeFormats are the containers -recode accepts, all of which yt-dlp's
+// --recode-video can produce with ffmpeg
+var recodeFormats = []string{"mp4", "mkv", "webm", "mov"}
+
+// rateLimitRegex matches the rates yt-dlp's --limit-rate accepts, e.g. 50K or 4.2M
+var rateLimitRegex = regexp.MustCompile(`^(?i)\d+(?:\.\d+)?[KMGTPEZY]?$`)
+
+// fileSizeRegex matches the sizes yt-dlp's --max-filesize accepts, which it
+// parses like --limit-rate
+var fileSizeRegex = rateLimitRegex
+
+// buildYtDlpArgs assembles the yt-dlp arguments for a single video, where
+// cookiesFile is a Netscape cookies file (or empty for no cookies)
+func buildYtDlpArgs(video VideoEntry, cookiesFile, pathFile string, config Config) []string {
+	var args []string
+
+	if cookiesFile != "" {
+		args = append(args, "--cookies", cookiesFile)
+	}
+
+	// Keep yt-dlp's progress output out of quiet runs; errors are still printed
+	if config.logLevel() == levelQuiet {
+		args = append(args, "--quiet", "--no-progress")
+	}
+
+	// Downloads set one proxy of several; anything else uses the first
+	if proxy := firstProxy(config.Proxy); proxy != "" {
+		args = append(args, "--proxy", proxy)
+	}
+
+	// Download with the same User-Agent the page was scraped with
+	if config.UserAgent != "" {
+		args = append(args, "--user-agent", config.UserAgent)
+	}
+
+	if config.RateLimit != "" {
+		args = append(args, "--limit-rate", config.RateLimit)
+	}
+
+	if config.MaxFilesize != "" {
+		args = append(args, "--max-filesize", config.MaxFilesize)
+	}
+
+	// Re-encode with ffmpeg when the download comes in a different container
+	if config.Recode != "" {
+		args = append(args, "--recode-video", config.Recode)
+	}
+
+	// Some networks stall on IPv6, so allow forcing IPv4
+	if config.ForceIPv4 {
+		args = append(args, "--force-ipv4")
+	}
+
+	// Keep the poster as its own image next to the video
+	if config.WriteThumbnail {
+		args = append(args, "--write-thumbnail")
+	}
+
+	if config.EmbedMetadata {
+		args = append(args, "--embed-metadata", "--embed-thumbnail")
+		// Prefer the lesson title over the platform's title, which also names the file
+		if video.Title != "" {
+			args = append(args, "--replace-in-metadata", "title", "(?s).+", escapeRegexReplacement(video.Title))
+		}
+	} else if video.MediaURL != "" && video.Title != "" {
+		// A captured stream has no title of its own, just the manifest's file name
+		args = append(args, "--replace-in-metadata", "title", "(?s).+", escapeRegexReplacement(video.Title))
+	}
+
+	// Streams captured from the page are usually only served to that page
+	if video.MediaURL != "" {
+		args = append(args, "--referer", video.URL)
+	}
+
+	// Print the available formats or the output filename only, without downloading
+	if config.ListFormats {
+		args = append(args, "--list-formats")
+	} else if config.ListOutput {
+		args = append(args, "--skip-download", "--print", "filename")
+	} else if config.Progress {
+		args = append(args, "--newline", "--progress-template", ytDlpProgressTemplate)
+	}
+
+	// Record where the video ends up for the report and -json
+	if pathFile != "" {
+		args = append(args, "--print-to-file", "after_move:filepath", pathFile)
+	}
+
+	// Leave finished files alone unless -overwrite asks to replace them
+	if config.Overwrite {
+		args = append(args, "--force-overwrites")
+	} else {
+		args = append(args, "--no-overwrites")
+	}
+
+	args = append(args,
+		"-o", outputTemplate(video, config),
+		"--no-warnings",
+	)
+
+	// Extra arguments go last so they can override the ones above; they were
+	// already checked in validateConfig
+	extra, _ := splitArgs(config.YtDlpArgs)
+	args = append(args, extra...)
+
+	if video.MediaURL != "" {
+		return append(args, video.MediaURL)
+	}
+	return append(args, video.URL)
+}
+
+// escapeRegexReplacement escapes s for use as the replacement of yt-dlp's
+// --replace-in-metadata, where backslashes start group references
+func escapeRegexReplacement(s string) string {
+	return strings.ReplaceAll(s, `\`, `\\`)
+}
+
+// outputTemplate returns the yt-dlp output template for a video
+func outputTemplate(video VideoEntry, config Config) string {
+	template := config.FilenameTemplate
+	if template == "" {
+		template = defaultFilenameTemplate
+	}
+	expanded := expandFilenameTemplate(template, video)
+	if config.NumberFiles {
+		expanded = numberFilename(expanded, video.Number)
+	}
+	return filepath.Join(config.OutputDir, expanded)
+}
+
+// resolveOutputFilename asks yt-dlp which file a video would be saved as
+func resolveOutputFilename(video VideoEntry, config Config) (string, error) {
+	cookiesFile, cleanup, err := prepareYtDlpCookies(config)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	config.ListOutput = true
+	args := buildYtDlpArgs(video, cookiesFile, "", config)
+
+	console.Debug("Running:", config.YtDlpPath, strings.Join(args, " "))
+	cmd := exec.Command(config.YtDlpPath, args...)
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1]), nil
+}
+
+// listOutputFiles prints the file each video would be saved as, without downloading
+func listOutputFiles(videos []VideoEntry, config Config) {
+	for i, video := range videos {
+		path, err := resolveOutputFilename(video, config)
+		if err != nil {
+			console.Errorf("[%d/%d] %s: %v", i+1, len(videos), video.URL, err)
+			continue
+		}
+		if config.JSON {
+			events.Emit(Event{Type: eventOutput, Index: i + 1, Total: len(videos), URL: video.URL, File: path})
+			continue
+		}
+		fmt.Println(path)
+	}
+}
+
+// printVideoURLs writes one video URL per line for -print-urls, with nothing
+// else on w so it can be piped into another tool
+func printVideoURLs(w io.Writer, videos []VideoEntry) error {
+	for _, video := range videos {
+		if _, err := fmt.Fprintln(w, video.URL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func convertJSONToNetscapeCookies(jsonFile string) (string, error) {
+	content, err := os.ReadFile(jsonFile)
+	if err != nil {
+		return "", err
+	}
+	return writeNetscapeCookiesFromJSON(stripBOM(content))
+}
+
+// writeNetscapeCookiesFromJSON converts JSON cookie content into a temporary
+// Netscape cookies file and returns its path
+func writeNetscapeCookiesFromJSON(content []byte) (string, error) {
+	var jsonCookies []JSONCookie
+	if err := json.Unmarshal(content, &jsonCookies); err != nil {
+		return "", err
+	}
+
+	// Create temporary file
+	tmpFile, err := os.CreateTemp("", "cookies-*.txt")
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = tmpFile.Close()
+	}()
+
+	// Write header
+	fmt.Fprintln(tmpFile, "# Netscape HTTP Cookie File")
+	fmt.Fprintln(tmpFile, "# This file was generated by skool-downloader")
+
+	// Write cookies
+	now := time.Now()
+	for _, c := range jsonCookies {
+		host := c.Host
+		if !strings.HasPrefix(host, ".") && strings.Count(host, ".") > 1 {
+			host = "." + host
+		}
+
+		secure := "FALSE"
+		if c.IsSecure == 1 {
+			secure = "TRUE"
+		}
+
+		// HttpOnly cookies get the same prefix parseNetscapeCookies reads
+		if c.IsHttpOnly == 1 {
+			host = netscapeHTTPOnlyPrefix + host
+		}
+
+		// Format: DOMAIN FLAG PATH SECURE EXPIRY NAME VALUE
+		if _, err := fmt.Fprintf(tmpFile, "%s\tTRUE\t%s\t%s\t%d\t%s\t%s\n",
+			host, c.Path, secure, netscapeExpiry(c.Expiry, now), c.Name, c.Value); err != nil {
+			return "", err
+		}
+	}
+
+	return tmpFile.Name(), nil
+}
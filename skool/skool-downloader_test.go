@@ -0,0 +1,1391 @@
+package skool
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+)
+
+func TestExtractLoomURLs(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected []string
+	}{
+		{
+			name:     "Empty HTML",
+			html:     "",
+			expected: []string{},
+		},
+		{
+			name:     "No Loom URLs",
+			html:     "<html><body>No videos here</body></html>",
+			expected: []string{},
+		},
+		{
+			name:     "Single share URL",
+			html:     `<html><body><a href="https://www.loom.com/share/abc123">Video</a></body></html>`,
+			expected: []string{"https://www.loom.com/share/abc123"},
+		},
+		{
+			name:     "Single share URL without www",
+			html:     `<html><body><a href="https://loom.com/share/xyz789">Video</a></body></html>`,
+			expected: []string{"https://loom.com/share/xyz789"},
+		},
+		{
+			name:     "Single embed URL",
+			html:     `<html><body><iframe src="https://www.loom.com/embed/def456"></iframe></body></html>`,
+			expected: []string{"https://www.loom.com/share/def456"},
+		},
+		{
+			name:     "Multiple URLs",
+			html:     `<html><body><a href="https://www.loom.com/share/abc123">Video1</a><a href="https://loom.com/share/xyz789">Video2</a></body></html>`,
+			expected: []string{"https://www.loom.com/share/abc123", "https://loom.com/share/xyz789"},
+		},
+		{
+			name:     "Duplicate URLs",
+			html:     `<html><body><a href="https://www.loom.com/share/abc123">Video1</a><a href="https://www.loom.com/share/abc123">Video2</a></body></html>`,
+			expected: []string{"https://www.loom.com/share/abc123"},
+		},
+		{
+			name:     "Mix of share and embed URLs",
+			html:     `<html><body><a href="https://www.loom.com/share/abc123">Video1</a><iframe src="https://loom.com/embed/def456"></iframe></body></html>`,
+			expected: []string{"https://www.loom.com/share/abc123", "https://www.loom.com/share/def456"},
+		},
+		{
+			name:     "Embed and share of same video",
+			html:     `<html><body><a href="https://www.loom.com/share/abc123">Video1</a><iframe src="https://loom.com/embed/abc123"></iframe></body></html>`,
+			expected: []string{"https://www.loom.com/share/abc123"},
+		},
+		{
+			name:     "Google Drive file link",
+			html:     `<html><body><a href="https://drive.google.com/file/d/1AbC_dEf-GhI/view?usp=sharing">Drive</a></body></html>`,
+			expected: []string{"https://drive.google.com/file/d/1AbC_dEf-GhI/view"},
+		},
+		{
+			name:     "Direct mp4 link",
+			html:     `<html><body><video src="https://cdn.example.com/course/lesson-1.mp4?token=abc"></video><img src="https://cdn.example.com/poster.jpg"></body></html>`,
+			expected: []string{"https://cdn.example.com/course/lesson-1.mp4?token=abc"},
+		},
+		{
+			name:     "Loom folder URL is skipped",
+			html:     `<html><body><a href="https://www.loom.com/share/folder/9f8e7d6c5b4a">Folder</a><a href="https://www.loom.com/share/abc123">Video</a></body></html>`,
+			expected: []string{"https://www.loom.com/share/abc123"},
+		},
+		{
+			name:     "Loom profile URL is skipped",
+			html:     `<html><body><a href="https://www.loom.com/share/profile">Profile</a><a href="https://www.loom.com/profile/jane-doe">Profile</a></body></html>`,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := extractLoomURLs(tt.html)
+			// Handle nil vs empty slice comparison
+			if len(result) == 0 && len(tt.expected) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("extractLoomURLs() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsPlaceholderVideoLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		link     string
+		expected bool
+	}{
+		{name: "Empty", link: "", expected: false},
+		{name: "Whitespace", link: "   ", expected: false},
+		{name: "Coming soon text", link: "Coming Soon", expected: true},
+		{name: "About blank", link: "about:blank", expected: true},
+		{name: "Loom share without ID", link: "https://www.loom.com/share/", expected: true},
+		{name: "YouTube watch without ID", link: "https://www.youtube.com/watch?v=", expected: true},
+		{name: "Loom video", link: "https://www.loom.com/share/abc123", expected: false},
+		{name: "YouTube video", link: "https://youtu.be/dQw4w9WgXcQ", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPlaceholderVideoLink(tt.link); got != tt.expected {
+				t.Errorf("isPlaceholderVideoLink(%q) = %v, want %v", tt.link, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractLoomURLsFromNextData_SkipsPlaceholders(t *testing.T) {
+	lesson := func(title, videoLink string) interface{} {
+		return map[string]interface{}{
+			"course": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"title":     title,
+					"videoLink": videoLink,
+				},
+			},
+		}
+	}
+	data := map[string]interface{}{
+		"props": map[string]interface{}{
+			"pageProps": map[string]interface{}{
+				"course": map[string]interface{}{
+					"children": []interface{}{
+						lesson("Ready", "https://www.loom.com/share/abc123"),
+						lesson("Empty", ""),
+						lesson("Placeholder", "https://www.loom.com/share/"),
+					},
+				},
+			},
+		},
+	}
+
+	result := extractLoomURLsFromNextData(data)
+	expected := []string{"https://www.loom.com/share/abc123"}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("extractLoomURLsFromNextData() = %v, want %v", result, expected)
+	}
+}
+
+func TestParseInt64(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  int64
+		shouldErr bool
+	}{
+		{
+			name:      "Valid positive number",
+			input:     "12345",
+			expected:  12345,
+			shouldErr: false,
+		},
+		{
+			name:      "Valid zero",
+			input:     "0",
+			expected:  0,
+			shouldErr: false,
+		},
+		{
+			name:      "Valid negative number",
+			input:     "-999",
+			expected:  -999,
+			shouldErr: false,
+		},
+		{
+			name:      "Invalid string",
+			input:     "abc",
+			expected:  0,
+			shouldErr: true,
+		},
+		{
+			name:      "Empty string",
+			input:     "",
+			expected:  0,
+			shouldErr: true,
+		},
+		{
+			name:      "Large number",
+			input:     "9223372036854775807", // max int64
+			expected:  9223372036854775807,
+			shouldErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseInt64(tt.input)
+			if tt.shouldErr {
+				if err == nil {
+					t.Errorf("parseInt64(%q) expected error, got nil", tt.input)
+				}
+			} else {
+				if err != nil {
+					t.Errorf("parseInt64(%q) unexpected error: %v", tt.input, err)
+				}
+				if result != tt.expected {
+					t.Errorf("parseInt64(%q) = %d, want %d", tt.input, result, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertJSONToNetscapeCookies(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonFile := filepath.Join(tmpDir, "cookies.json")
+
+	jsonContent := `[
+		{
+			"host": ".skool.com",
+			"name": "test_cookie",
+			"value": "test_value",
+			"path": "/",
+			"expiry": 1700000000,
+			"isSecure": 1,
+			"isHttpOnly": 1,
+			"sameSite": 0
+		},
+		{
+			"host": "www.skool.com",
+			"name": "another_cookie",
+			"value": "another_value",
+			"path": "/path",
+			"expiry": 1800000000,
+			"isSecure": 0,
+			"isHttpOnly": 0,
+			"sameSite": 1
+		}
+	]`
+
+	if err := os.WriteFile(jsonFile, []byte(jsonContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	// Test conversion
+	netscapeFile, err := convertJSONToNetscapeCookies(jsonFile)
+	if err != nil {
+		t.Fatalf("convertJSONToNetscapeCookies() error = %v", err)
+	}
+	defer func() {
+		if err := os.Remove(netscapeFile); err != nil {
+			t.Logf("Failed to remove temp file: %v", err)
+		}
+	}()
+
+	content, err := os.ReadFile(netscapeFile)
+	if err != nil {
+		t.Fatalf("Failed to read converted file: %v", err)
+	}
+
+	contentStr := string(content)
+
+	if !strings.Contains(contentStr, "# Netscape HTTP Cookie File") {
+		t.Error("Missing Netscape header")
+	}
+
+	if !strings.Contains(contentStr, "test_cookie") {
+		t.Error("Missing test_cookie in output")
+	}
+	if !strings.Contains(contentStr, "test_value") {
+		t.Error("Missing test_value in output")
+	}
+	if !strings.Contains(contentStr, "another_cookie") {
+		t.Error("Missing another_cookie in output")
+	}
+	if !strings.Contains(contentStr, "TRUE") { // secure flag
+		t.Error("Missing TRUE flag for secure cookie")
+	}
+	if !strings.Contains(contentStr, "FALSE") { // non-secure flag
+		t.Error("Missing FALSE flag for non-secure cookie")
+	}
+
+	// The JSON input is longer than the converted file and must not survive conversion
+	if strings.Contains(contentStr, jsonContent) {
+		t.Error("Converted file still contains the raw JSON cookies")
+	}
+}
+
+func TestConvertJSONToNetscapeCookies_SessionCookie(t *testing.T) {
+	jsonFile := filepath.Join(t.TempDir(), "cookies.json")
+	content := `[{"host": ".skool.com", "name": "auth_token", "value": "abc", "path": "/", "expiry": 0}]`
+	if err := os.WriteFile(jsonFile, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	netscapeFile, err := convertJSONToNetscapeCookies(jsonFile)
+	if err != nil {
+		t.Fatalf("convertJSONToNetscapeCookies() error = %v", err)
+	}
+	defer func() {
+		_ = os.Remove(netscapeFile)
+	}()
+
+	converted, err := os.ReadFile(netscapeFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(string(converted), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 7 || fields[5] != "auth_token" {
+			continue
+		}
+		expiry, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil || expiry <= time.Now().Unix() {
+			t.Errorf("Expected a session cookie to get a future expiry yt-dlp keeps, got %q", fields[4])
+		}
+		return
+	}
+	t.Fatalf("auth_token missing from converted file:\n%s", converted)
+}
+
+func TestNetscapeExpiry(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	if got := netscapeExpiry(1800000000, now); got != 1800000000 {
+		t.Errorf("netscapeExpiry() changed a real expiry to %d", got)
+	}
+	if got := netscapeExpiry(0, now); got != now.Add(sessionCookieLifetime).Unix() {
+		t.Errorf("netscapeExpiry(0) = %d, want now + sessionCookieLifetime", got)
+	}
+}
+
+func TestConvertJSONToNetscapeCookies_InvalidJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonFile := filepath.Join(tmpDir, "invalid.json")
+
+	if err := os.WriteFile(jsonFile, []byte("invalid json"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	_, err := convertJSONToNetscapeCookies(jsonFile)
+	if err == nil {
+		t.Error("Expected error for invalid JSON, got nil")
+	}
+}
+
+func TestConvertJSONToNetscapeCookies_NonexistentFile(t *testing.T) {
+	_, err := convertJSONToNetscapeCookies("/nonexistent/file.json")
+	if err == nil {
+		t.Error("Expected error for nonexistent file, got nil")
+	}
+}
+
+func TestParseJSONCookies(t *testing.T) {
+	jsonContent := []byte(`[
+		{
+			"host": ".example.com",
+			"name": "cookie1",
+			"value": "value1",
+			"path": "/",
+			"expiry": 1700000000,
+			"isSecure": 1,
+			"isHttpOnly": 1,
+			"sameSite": 1
+		},
+		{
+			"host": "www.example.com",
+			"name": "cookie2",
+			"value": "value2",
+			"path": "/test",
+			"expiry": 0,
+			"isSecure": 0,
+			"isHttpOnly": 0,
+			"sameSite": 0
+		}
+	]`)
+
+	cookies, err := parseJSONCookies(jsonContent, sameSiteZeroUnset)
+	if err != nil {
+		t.Fatalf("parseJSONCookies() error = %v", err)
+	}
+
+	if len(cookies) != 2 {
+		t.Errorf("Expected 2 cookies, got %d", len(cookies))
+	}
+
+	if cookies[0].Name != "cookie1" {
+		t.Errorf("Expected name 'cookie1', got '%s'", cookies[0].Name)
+	}
+	if cookies[0].Value != "value1" {
+		t.Errorf("Expected value 'value1', got '%s'", cookies[0].Value)
+	}
+	if cookies[0].Domain != ".example.com" {
+		t.Errorf("Expected domain '.example.com', got '%s'", cookies[0].Domain)
+	}
+	if !cookies[0].Secure {
+		t.Error("Expected Secure to be true")
+	}
+	if !cookies[0].HTTPOnly {
+		t.Error("Expected HTTPOnly to be true")
+	}
+	if cookies[0].SameSite != network.CookieSameSiteLax {
+		t.Errorf("Expected SameSite Lax, got %v", cookies[0].SameSite)
+	}
+
+	if cookies[1].Name != "cookie2" {
+		t.Errorf("Expected name 'cookie2', got '%s'", cookies[1].Name)
+	}
+	if cookies[1].Domain != "www.example.com" {
+		t.Errorf("Expected domain 'www.example.com', got '%s'", cookies[1].Domain)
+	}
+	if cookies[1].Secure {
+		t.Error("Expected Secure to be false")
+	}
+	if cookies[1].HTTPOnly {
+		t.Error("Expected HTTPOnly to be false")
+	}
+}
+
+func TestParseJSONCookies_InvalidJSON(t *testing.T) {
+	_, err := parseJSONCookies([]byte("invalid json"), sameSiteZeroUnset)
+	if err == nil {
+		t.Error("Expected error for invalid JSON, got nil")
+	}
+}
+
+func TestParseNetscapeCookies(t *testing.T) {
+	netscapeContent := []byte(`# Netscape HTTP Cookie File
+# This is a comment
+.example.com	TRUE	/	TRUE	1700000000	cookie1	value1
+www.example.com	TRUE	/test	FALSE	0	cookie2	value2
+
+# Another comment
+.test.com	TRUE	/	TRUE	1800000000	cookie3	value3`)
+
+	cookies, err := parseNetscapeCookies(netscapeContent)
+	if err != nil {
+		t.Fatalf("parseNetscapeCookies() error = %v", err)
+	}
+
+	if len(cookies) != 3 {
+		t.Errorf("Expected 3 cookies, got %d", len(cookies))
+	}
+
+	if cookies[0].Name != "cookie1" {
+		t.Errorf("Expected name 'cookie1', got '%s'", cookies[0].Name)
+	}
+	if cookies[0].Value != "value1" {
+		t.Errorf("Expected value 'value1', got '%s'", cookies[0].Value)
+	}
+	if cookies[0].Domain != ".example.com" {
+		t.Errorf("Expected domain '.example.com', got '%s'", cookies[0].Domain)
+	}
+	if !cookies[0].Secure {
+		t.Error("Expected Secure to be true")
+	}
+
+	if cookies[1].Name != "cookie2" {
+		t.Errorf("Expected name 'cookie2', got '%s'", cookies[1].Name)
+	}
+	if cookies[1].Path != "/test" {
+		t.Errorf("Expected path '/test', got '%s'", cookies[1].Path)
+	}
+	if cookies[1].Secure {
+		t.Error("Expected Secure to be false")
+	}
+
+	if cookies[2].Name != "cookie3" {
+		t.Errorf("Expected name 'cookie3', got '%s'", cookies[2].Name)
+	}
+
+	for _, c := range cookies {
+		if c.HTTPOnly {
+			t.Errorf("Expected HTTPOnly to be false for %s", c.Name)
+		}
+	}
+}
+
+func TestParseCookies_SubdomainDots(t *testing.T) {
+	jsonContent := []byte(`[
+		{"host": ".skool.com", "name": "auth_token", "value": "a", "path": "/"},
+		{"host": "www.skool.com", "name": "host_only", "value": "b", "path": "/"}
+	]`)
+	netscapeContent := []byte(".skool.com\tTRUE\t/\tTRUE\t0\tauth_token\ta\n" +
+		"www.skool.com\tFALSE\t/\tTRUE\t0\thost_only\tb\n")
+
+	parsers := map[string]func() ([]*network.CookieParam, error){
+		"JSON":     func() ([]*network.CookieParam, error) { return parseJSONCookies(jsonContent, sameSiteZeroUnset) },
+		"Netscape": func() ([]*network.CookieParam, error) { return parseNetscapeCookies(netscapeContent) },
+	}
+
+	for name, parse := range parsers {
+		t.Run(name, func(t *testing.T) {
+			cookies, err := parse()
+			if err != nil {
+				t.Fatalf("parse error = %v", err)
+			}
+			if len(cookies) != 2 {
+				t.Fatalf("Expected 2 cookies, got %d", len(cookies))
+			}
+			if cookies[0].Domain != ".skool.com" {
+				t.Errorf("Expected domain '.skool.com', got '%s'", cookies[0].Domain)
+			}
+			if cookies[1].Domain != "www.skool.com" {
+				t.Errorf("Expected domain 'www.skool.com', got '%s'", cookies[1].Domain)
+			}
+		})
+	}
+}
+
+func TestParseNetscapeCookies_HttpOnlyPrefix(t *testing.T) {
+	netscapeContent := []byte(`# Netscape HTTP Cookie File
+#HttpOnly_.skool.com	TRUE	/	TRUE	1700000000	auth_token	secret
+.skool.com	TRUE	/	FALSE	0	client_id	abc
+#HttpOnly_www.skool.com	FALSE	/	TRUE	0	session	xyz
+# A regular comment	TRUE	/	TRUE	0	not	a-cookie`)
+
+	cookies, err := parseNetscapeCookies(netscapeContent)
+	if err != nil {
+		t.Fatalf("parseNetscapeCookies() error = %v", err)
+	}
+
+	if len(cookies) != 3 {
+		t.Fatalf("Expected 3 cookies, got %d", len(cookies))
+	}
+
+	tests := []struct {
+		name     string
+		domain   string
+		httpOnly bool
+	}{
+		{name: "auth_token", domain: ".skool.com", httpOnly: true},
+		{name: "client_id", domain: ".skool.com", httpOnly: false},
+		{name: "session", domain: "www.skool.com", httpOnly: true},
+	}
+
+	for i, tt := range tests {
+		if cookies[i].Name != tt.name {
+			t.Errorf("Cookie %d: expected name %q, got %q", i, tt.name, cookies[i].Name)
+		}
+		if cookies[i].Domain != tt.domain {
+			t.Errorf("Cookie %s: expected domain %q, got %q", tt.name, tt.domain, cookies[i].Domain)
+		}
+		if cookies[i].HTTPOnly != tt.httpOnly {
+			t.Errorf("Cookie %s: expected HTTPOnly %v, got %v", tt.name, tt.httpOnly, cookies[i].HTTPOnly)
+		}
+	}
+}
+
+func TestParseCookiesFile_JSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	jsonFile := filepath.Join(tmpDir, "cookies.json")
+
+	jsonContent := `[
+		{
+			"host": ".example.com",
+			"name": "test",
+			"value": "value",
+			"path": "/",
+			"expiry": 1700000000,
+			"isSecure": 1,
+			"isHttpOnly": 1,
+			"sameSite": 0
+		}
+	]`
+
+	if err := os.WriteFile(jsonFile, []byte(jsonContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cookies, err := parseCookiesFile(jsonFile)
+	if err != nil {
+		t.Fatalf("parseCookiesFile() error = %v", err)
+	}
+
+	if len(cookies) != 1 {
+		t.Errorf("Expected 1 cookie, got %d", len(cookies))
+	}
+	if cookies[0].Name != "test" {
+		t.Errorf("Expected name 'test', got '%s'", cookies[0].Name)
+	}
+}
+
+func TestParseCookiesFile_Netscape(t *testing.T) {
+	tmpDir := t.TempDir()
+	txtFile := filepath.Join(tmpDir, "cookies.txt")
+
+	txtContent := `# Netscape HTTP Cookie File
+.example.com	TRUE	/	TRUE	1700000000	test	value`
+
+	if err := os.WriteFile(txtFile, []byte(txtContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cookies, err := parseCookiesFile(txtFile)
+	if err != nil {
+		t.Fatalf("parseCookiesFile() error = %v", err)
+	}
+
+	if len(cookies) != 1 {
+		t.Errorf("Expected 1 cookie, got %d", len(cookies))
+	}
+	if cookies[0].Name != "test" {
+		t.Errorf("Expected name 'test', got '%s'", cookies[0].Name)
+	}
+}
+
+func TestParseCookiesFile_AutoDetectJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, "cookies") // no extension
+
+	jsonContent := `[
+		{
+			"host": ".example.com",
+			"name": "test",
+			"value": "value",
+			"path": "/",
+			"expiry": 1700000000,
+			"isSecure": 1,
+			"isHttpOnly": 1,
+			"sameSite": 0
+		}
+	]`
+
+	if err := os.WriteFile(file, []byte(jsonContent), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	cookies, err := parseCookiesFile(file)
+	if err != nil {
+		t.Fatalf("parseCookiesFile() error = %v", err)
+	}
+
+	if len(cookies) != 1 {
+		t.Errorf("Expected 1 cookie, got %d", len(cookies))
+	}
+}
+
+func TestParseCookiesFile_NonexistentFile(t *testing.T) {
+	_, err := parseCookiesFile("/nonexistent/file.json")
+	if err == nil {
+		t.Error("Expected error for nonexistent file, got nil")
+	}
+}
+
+func TestStageStdinCookies_JSON(t *testing.T) {
+	jsonContent := `[
+		{
+			"host": ".example.com",
+			"name": "test",
+			"value": "value",
+			"path": "/",
+			"expiry": 1700000000,
+			"isSecure": 1,
+			"isHttpOnly": 1,
+			"sameSite": 0
+		}
+	]`
+
+	tmpFile, err := stageStdinCookies(strings.NewReader(jsonContent))
+	if err != nil {
+		t.Fatalf("stageStdinCookies() error = %v", err)
+	}
+	defer func() {
+		_ = os.Remove(tmpFile)
+	}()
+
+	if filepath.Ext(tmpFile) != ".json" {
+		t.Errorf("Expected .json temp file, got %s", tmpFile)
+	}
+
+	cookies, err := parseCookiesFile(tmpFile)
+	if err != nil {
+		t.Fatalf("parseCookiesFile() error = %v", err)
+	}
+	if len(cookies) != 1 {
+		t.Fatalf("Expected 1 cookie, got %d", len(cookies))
+	}
+	if cookies[0].Name != "test" {
+		t.Errorf("Expected name 'test', got '%s'", cookies[0].Name)
+	}
+}
+
+func TestStageStdinCookies_Netscape(t *testing.T) {
+	txtContent := `# Netscape HTTP Cookie File
+.example.com	TRUE	/	TRUE	1700000000	test	value`
+
+	tmpFile, err := stageStdinCookies(strings.NewReader(txtContent))
+	if err != nil {
+		t.Fatalf("stageStdinCookies() error = %v", err)
+	}
+	defer func() {
+		_ = os.Remove(tmpFile)
+	}()
+
+	if filepath.Ext(tmpFile) != ".txt" {
+		t.Errorf("Expected .txt temp file, got %s", tmpFile)
+	}
+
+	cookies, err := parseCookiesFile(tmpFile)
+	if err != nil {
+		t.Fatalf("parseCookiesFile() error = %v", err)
+	}
+	if len(cookies) != 1 {
+		t.Errorf("Expected 1 cookie, got %d", len(cookies))
+	}
+}
+
+func TestStageStdinCookies_Empty(t *testing.T) {
+	_, err := stageStdinCookies(strings.NewReader("  \n"))
+	if err == nil {
+		t.Error("Expected error for empty stdin, got nil")
+	}
+}
+
+func TestValidateConfig_NoURL(t *testing.T) {
+	// This test will cause os.Exit(1), so we skip it in normal test runs
+	// It's documented here for completeness
+	t.Skip("Skipping test that calls os.Exit")
+}
+
+func TestValidateConfig_NoAuth(t *testing.T) {
+	// This test will cause os.Exit(1), so we skip it in normal test runs
+	// It's documented here for completeness
+	t.Skip("Skipping test that calls os.Exit")
+}
+
+func TestFindBrowser_CustomAbsolutePath(t *testing.T) {
+	tmpDir := t.TempDir()
+	fakeBrowser := filepath.Join(tmpDir, "fake-browser")
+	if err := os.WriteFile(fakeBrowser, []byte{}, 0755); err != nil {
+		t.Fatalf("Failed to create fake browser file: %v", err)
+	}
+
+	path, err := findBrowser(fakeBrowser)
+	if err != nil {
+		t.Fatalf("findBrowser() error = %v", err)
+	}
+	if path != fakeBrowser {
+		t.Errorf("findBrowser() = %v, want %v", path, fakeBrowser)
+	}
+}
+
+func TestFindBrowser_InvalidCustomPath(t *testing.T) {
+	_, err := findBrowser("/nonexistent/path/to/browser")
+	if err == nil {
+		t.Error("Expected error for nonexistent browser path, got nil")
+	}
+}
+
+func TestFindBrowser_InvalidBareCommand(t *testing.T) {
+	_, err := findBrowser("skool-nonexistent-browser-xyz")
+	if err == nil {
+		t.Error("Expected error for unknown browser command, got nil")
+	}
+}
+
+func TestGetBrowserCandidates_NotEmpty(t *testing.T) {
+	candidates := getBrowserCandidates()
+	if len(candidates) == 0 {
+		t.Error("getBrowserCandidates() returned an empty list")
+	}
+}
+
+func TestBrowserCandidates_PerOS(t *testing.T) {
+	env := map[string]string{
+		"PROGRAMFILES": `C:\Program Files`,
+		"LOCALAPPDATA": `C:\Users\me\AppData\Local`,
+	}
+	getenv := func(key string) string { return env[key] }
+
+	tests := []struct {
+		goos string
+		want []string
+	}{
+		{"linux", []string{"chromium", "brave-browser", "brave", "vivaldi", "vivaldi-stable", "opera"}},
+		{"windows", []string{
+			"msedge", "vivaldi", "opera",
+			filepath.Join(env["LOCALAPPDATA"], "Vivaldi", "Application", "vivaldi.exe"),
+			filepath.Join(env["LOCALAPPDATA"], "Programs", "Opera", "opera.exe"),
+		}},
+		{"darwin", []string{"/Applications/Google Chrome.app/Contents/MacOS/Google Chrome"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			candidates := browserCandidates(tt.goos, getenv)
+			for _, want := range tt.want {
+				if !slices.Contains(candidates, want) {
+					t.Errorf("Expected %q in the %s candidates: %v", want, tt.goos, candidates)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveYtDlp_AbsolutePath(t *testing.T) {
+	tmpDir := t.TempDir()
+	fakeYtDlp := filepath.Join(tmpDir, "yt-dlp_macos")
+	if err := os.WriteFile(fakeYtDlp, []byte{}, 0755); err != nil {
+		t.Fatalf("Failed to create fake yt-dlp file: %v", err)
+	}
+
+	path, err := resolveYtDlp(fakeYtDlp)
+	if err != nil {
+		t.Fatalf("resolveYtDlp() error = %v", err)
+	}
+	if path != fakeYtDlp {
+		t.Errorf("resolveYtDlp() = %v, want %v", path, fakeYtDlp)
+	}
+}
+
+func TestResolveYtDlp_InvalidAbsolutePath(t *testing.T) {
+	_, err := resolveYtDlp("/nonexistent/path/to/yt-dlp")
+	if err == nil {
+		t.Error("Expected error for nonexistent yt-dlp path, got nil")
+	}
+}
+
+func TestResolveYtDlp_InvalidBareCommand(t *testing.T) {
+	_, err := resolveYtDlp("skool-nonexistent-ytdlp-xyz")
+	if err == nil {
+		t.Error("Expected error for unknown yt-dlp command, got nil")
+	}
+}
+
+func TestIsLoomVideoURL(t *testing.T) {
+	tests := map[string]bool{
+		"https://www.loom.com/share/abc123":         true,
+		"https://loom.com/share/0123456789abcdef":   true,
+		"https://www.loom.com/share/folder":         false,
+		"https://www.loom.com/share/folder/abc123":  false,
+		"https://www.loom.com/share/Profile":        false,
+		"https://www.loom.com/embed/abc123":         false,
+		"https://www.loom.com/share/abc123?sid=xyz": false,
+	}
+
+	for url, expected := range tests {
+		if got := isLoomVideoURL(url); got != expected {
+			t.Errorf("isLoomVideoURL(%q) = %v, want %v", url, got, expected)
+		}
+	}
+}
+
+func TestRateLimitRegex(t *testing.T) {
+	valid := []string{"500", "50K", "2M", "4.2M", "1g", "10k"}
+	invalid := []string{"", "fast", "2 M", "2MB", "-1M", "1.M", "M"}
+
+	for _, rate := range valid {
+		if !rateLimitRegex.MatchString(rate) {
+			t.Errorf("Expected %q to be a valid rate limit", rate)
+		}
+	}
+	for _, rate := range invalid {
+		if rateLimitRegex.MatchString(rate) {
+			t.Errorf("Expected %q to be rejected", rate)
+		}
+	}
+}
+
+func TestBuildYtDlpArgs(t *testing.T) {
+	tests := []struct {
+		name        string
+		cookiesFile string
+		pathFile    string
+		title       string
+		config      Config
+		expected    []string
+	}{
+		{
+			name:     "Defaults",
+			config:   Config{OutputDir: "downloads"},
+			expected: []string{"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123"},
+		},
+		{
+			name:        "Cookies and force IPv4",
+			cookiesFile: "cookies.txt",
+			config:      Config{OutputDir: "downloads", ForceIPv4: true},
+			expected: []string{
+				"--cookies", "cookies.txt",
+				"--force-ipv4",
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:   "Recode",
+			config: Config{OutputDir: "downloads", Recode: "mp4"},
+			expected: []string{
+				"--recode-video", "mp4",
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:   "Proxy",
+			config: Config{OutputDir: "downloads", Proxy: "socks5://127.0.0.1:1080"},
+			expected: []string{
+				"--proxy", "socks5://127.0.0.1:1080",
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:   "First of several proxies",
+			config: Config{OutputDir: "downloads", Proxy: "socks5://127.0.0.1:1080,socks5://127.0.0.2:1080"},
+			expected: []string{
+				"--proxy", "socks5://127.0.0.1:1080",
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:   "User agent",
+			config: Config{OutputDir: "downloads", UserAgent: "TestAgent/1.0"},
+			expected: []string{
+				"--user-agent", "TestAgent/1.0",
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:   "Extra yt-dlp arguments",
+			config: Config{OutputDir: "downloads", YtDlpArgs: `--concurrent-fragments 4 --match-filter "duration > 60"`},
+			expected: []string{
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings",
+				"--concurrent-fragments", "4", "--match-filter", "duration > 60",
+				"https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:   "Rate limit",
+			config: Config{OutputDir: "downloads", RateLimit: "2M"},
+			expected: []string{
+				"--limit-rate", "2M",
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:   "Max filesize",
+			config: Config{OutputDir: "downloads", RateLimit: "2M", MaxFilesize: "1.5G"},
+			expected: []string{
+				"--limit-rate", "2M",
+				"--max-filesize", "1.5G",
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:   "Write thumbnail",
+			config: Config{OutputDir: "downloads", WriteThumbnail: true, EmbedMetadata: true},
+			expected: []string{
+				"--write-thumbnail",
+				"--embed-metadata", "--embed-thumbnail",
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:   "Embed metadata without a lesson title",
+			config: Config{OutputDir: "downloads", EmbedMetadata: true},
+			expected: []string{
+				"--embed-metadata", "--embed-thumbnail",
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:   "Embed metadata with a lesson title",
+			title:  `Week 1\Intro`,
+			config: Config{OutputDir: "downloads", EmbedMetadata: true},
+			expected: []string{
+				"--embed-metadata", "--embed-thumbnail",
+				"--replace-in-metadata", "title", "(?s).+", `Week 1\\Intro`,
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:   "List output",
+			config: Config{OutputDir: "downloads", ListOutput: true},
+			expected: []string{
+				"--skip-download", "--print", "filename",
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:   "List formats",
+			config: Config{OutputDir: "downloads", ListFormats: true},
+			expected: []string{
+				"--list-formats",
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:   "Progress",
+			config: Config{OutputDir: "downloads", Progress: true},
+			expected: []string{
+				"--newline", "--progress-template", ytDlpProgressTemplate,
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:   "JSON",
+			config: Config{OutputDir: "downloads", JSON: true},
+			expected: []string{
+				"--quiet", "--no-progress",
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:     "Path file",
+			pathFile: "path.txt",
+			config:   Config{OutputDir: "downloads"},
+			expected: []string{
+				"--print-to-file", "after_move:filepath", "path.txt",
+				"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:   "Overwrite",
+			config: Config{OutputDir: "downloads", Overwrite: true},
+			expected: []string{
+				"--force-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123",
+			},
+		},
+		{
+			name:     "Quiet",
+			config:   Config{OutputDir: "downloads", Quiet: true},
+			expected: []string{"--quiet", "--no-progress", "--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://www.loom.com/share/abc123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := buildYtDlpArgs(VideoEntry{URL: "https://www.loom.com/share/abc123", Title: tt.title}, tt.cookiesFile, tt.pathFile, tt.config)
+			if !reflect.DeepEqual(args, tt.expected) {
+				t.Errorf("buildYtDlpArgs() = %v, want %v", args, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildYtDlpArgs_CapturedStream(t *testing.T) {
+	video := VideoEntry{URL: "https://player.example.com/v/42", Title: "Week 1", MediaURL: "https://cdn.example.com/42/master.m3u8"}
+	args := buildYtDlpArgs(video, "", "", Config{OutputDir: "downloads"})
+	expected := []string{
+		"--replace-in-metadata", "title", "(?s).+", "Week 1",
+		"--referer", "https://player.example.com/v/42",
+		"--no-overwrites", "-o", filepath.Join("downloads", "%(title)s.%(ext)s"), "--no-warnings", "https://cdn.example.com/42/master.m3u8",
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Errorf("buildYtDlpArgs() = %v, want %v", args, expected)
+	}
+}
+
+func TestOutputTemplate(t *testing.T) {
+	got := outputTemplate(VideoEntry{}, Config{OutputDir: filepath.Join("my", "course")})
+	want := filepath.Join("my", "course", "%(title)s.%(ext)s")
+	if got != want {
+		t.Errorf("outputTemplate() = %v, want %v", got, want)
+	}
+
+	video := VideoEntry{Title: "Welcome", Module: "Getting Started"}
+	got = outputTemplate(video, Config{OutputDir: "downloads", FilenameTemplate: "{module}/{lesson}.%(ext)s"})
+	want = filepath.Join("downloads", "Getting Started", "Welcome.%(ext)s")
+	if got != want {
+		t.Errorf("outputTemplate() = %v, want %v", got, want)
+	}
+}
+
+func TestWaitForNextData(t *testing.T) {
+	hydrated := `<html><script id="__NEXT_DATA__" type="application/json">{"props":{}}</script></html>`
+	bare := `<html><div id="__next"></div></html>`
+
+	tests := []struct {
+		name          string
+		pages         []string
+		retries       int
+		expectedCalls int
+		expectedHTML  string
+	}{
+		{name: "Hydrated on first load", pages: []string{hydrated}, retries: 3, expectedCalls: 1, expectedHTML: hydrated},
+		{name: "Hydrated after retries", pages: []string{bare, bare, hydrated}, retries: 3, expectedCalls: 3, expectedHTML: hydrated},
+		{name: "Never hydrated", pages: []string{bare, bare, bare, bare, bare}, retries: 3, expectedCalls: 4, expectedHTML: bare},
+		{name: "Retries disabled", pages: []string{bare, hydrated}, retries: 0, expectedCalls: 1, expectedHTML: bare},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			html, err := waitForNextData(func() (string, error) {
+				page := tt.pages[calls]
+				calls++
+				return page, nil
+			}, tt.retries, 0)
+			if err != nil {
+				t.Fatalf("waitForNextData() unexpected error: %v", err)
+			}
+			if calls != tt.expectedCalls {
+				t.Errorf("Expected %d fetches, got %d", tt.expectedCalls, calls)
+			}
+			if html != tt.expectedHTML {
+				t.Errorf("waitForNextData() = %q, want %q", html, tt.expectedHTML)
+			}
+		})
+	}
+}
+
+func TestWaitForNextData_FetchError(t *testing.T) {
+	_, err := waitForNextData(func() (string, error) {
+		return "", errors.New("target closed")
+	}, 3, 0)
+	if err == nil {
+		t.Error("waitForNextData() should return fetch errors")
+	}
+}
+
+func TestParseArgs_UserAgent(t *testing.T) {
+	config, err := parseArgs(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	if err != nil {
+		t.Fatalf("parseArgs failed: %v", err)
+	}
+	if config.UserAgent != defaultUserAgent {
+		t.Errorf("Expected the default User-Agent, got %q", config.UserAgent)
+	}
+
+	config, err = parseArgs(flag.NewFlagSet("test", flag.ContinueOnError), []string{"-user-agent", "TestAgent/1.0"})
+	if err != nil {
+		t.Fatalf("parseArgs failed: %v", err)
+	}
+	if config.UserAgent != "TestAgent/1.0" {
+		t.Errorf("Expected -user-agent to override the default, got %q", config.UserAgent)
+	}
+}
+
+func TestParseArgs_Environment(t *testing.T) {
+	t.Setenv(urlEnv, "https://www.skool.com/env/classroom")
+	t.Setenv(cookiesEnv, "env-cookies.json")
+	t.Setenv(emailEnv, "env@example.com")
+	t.Setenv(passwordEnv, "env-secret")
+
+	config, err := parseArgs(flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	if err != nil {
+		t.Fatalf("parseArgs failed: %v", err)
+	}
+	if config.SkoolURL != "https://www.skool.com/env/classroom" || config.CookiesFile != "env-cookies.json" ||
+		config.Email != "env@example.com" || config.Password != "env-secret" {
+		t.Errorf("Expected values from the environment, got url=%q cookies=%q email=%q", config.SkoolURL, config.CookiesFile, config.Email)
+	}
+
+	config, err = parseArgs(flag.NewFlagSet("test", flag.ContinueOnError), []string{
+		"-email", "flag@example.com",
+		"-password", "flag-secret",
+	})
+	if err != nil {
+		t.Fatalf("parseArgs failed: %v", err)
+	}
+	if config.Email != "flag@example.com" || config.Password != "flag-secret" {
+		t.Errorf("Expected flags to win over the environment, got email=%q", config.Email)
+	}
+	if config.SkoolURL != "https://www.skool.com/env/classroom" {
+		t.Errorf("Expected unset flags to still come from the environment, got url=%q", config.SkoolURL)
+	}
+	if config.CookiesFile != "" {
+		t.Errorf("Expected no cookies from the environment next to -email, got cookies=%q", config.CookiesFile)
+	}
+}
+
+func TestParseArgs_EnvironmentAuthYieldsToFlags(t *testing.T) {
+	t.Setenv(urlEnv, "https://www.skool.com/env/classroom")
+	t.Setenv(cookiesEnv, "env-cookies.json")
+	t.Setenv(emailEnv, "env@example.com")
+	t.Setenv(passwordEnv, "env-secret")
+	t.Setenv(authTokenEnv, "env-token")
+	t.Setenv(cookiesAuthEnv, "Bearer env")
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"Cookies", []string{"-cookies", "flag-cookies.json"}},
+		{"Auth token", []string{"-auth-token", "flag-token"}},
+		{"Manual login", []string{"-manual-login"}},
+		{"Cookies from browser", []string{"-cookies-from-browser", "firefox"}},
+		{"Profile", []string{"-profile-dir", t.TempDir()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := parseArgs(flag.NewFlagSet("test", flag.ContinueOnError), tt.args)
+			if err != nil {
+				t.Fatalf("parseArgs failed: %v", err)
+			}
+			if config.Email != "" || config.Password != "" {
+				t.Errorf("Expected no email login from the environment, got email=%q", config.Email)
+			}
+			if config.CookiesAuthHeader != "" {
+				t.Errorf("Expected no cookies header for a local file, got %q", config.CookiesAuthHeader)
+			}
+			// The only sign-in method left is the one passed, so it validates
+			if err := validateConfig(&config); err != nil {
+				t.Errorf("validateConfig() error: %v", err)
+			}
+		})
+	}
+}
+
+func TestParseArgs_EnvironmentCookiesAuthHeader(t *testing.T) {
+	t.Setenv(cookiesAuthEnv, "Bearer env")
+
+	config, err := parseArgs(flag.NewFlagSet("test", flag.ContinueOnError), []string{"-cookies", "https://secrets.example.com/cookies.json"})
+	if err != nil {
+		t.Fatalf("parseArgs failed: %v", err)
+	}
+	if config.CookiesAuthHeader != "Bearer env" {
+		t.Errorf("Expected the header from the environment for a cookies URL, got %q", config.CookiesAuthHeader)
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	valid := func() Config {
+		return Config{
+			SkoolURL:         "https://www.skool.com/test/classroom",
+			Email:            "user@example.com",
+			Password:         "secret",
+			Timeout:          browserTimeout,
+			LoginWait:        defaultLoginWait,
+			UserAgent:        defaultUserAgent,
+			FilenameTemplate: defaultFilenameTemplate,
+			Tabs:             1,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{"Valid", func(c *Config) {}, ""},
+		{"Missing URL", func(c *Config) { c.SkoolURL = "" }, ErrMissingURL.Error()},
+		{"Invalid URL", func(c *Config) { c.SkoolURL = "https://example.com/test" }, "invalid -url"},
+		{"No auth", func(c *Config) { c.Email, c.Password = "", "" }, "you must provide"},
+		{"Quiet and verbose", func(c *Config) { c.Quiet, c.Verbose = true, true }, "-quiet and -verbose"},
+		{"Negative retries", func(c *Config) { c.NextDataRetries = -1 }, "-next-data-retries"},
+		{"Unterminated -ytdlp-args quote", func(c *Config) { c.YtDlpArgs = `--match-filter "x` }, "invalid -ytdlp-args"},
+		{"Bad rate limit", func(c *Config) { c.RateLimit = "fast" }, "invalid -rate-limit"},
+		{"Max filesize", func(c *Config) { c.MaxFilesize = "500M" }, ""},
+		{"Negative download delay", func(c *Config) { c.DownloadDelay = -time.Second }, "-download-delay"},
+		{"Host resolver rules", func(c *Config) { c.HostResolverRules = "MAP www.skool.com 203.0.113.7" }, ""},
+		{"Invalid host resolver rules", func(c *Config) { c.HostResolverRules = "www.skool.com=203.0.113.7" }, "-host-resolver-rules"},
+		{"From HTML without -url or authentication", func(c *Config) { c.FromHTML, c.SkoolURL, c.Email, c.Password = "classroom.html", "", "", "" }, ""},
+		{"From HTML with -deep", func(c *Config) { c.FromHTML, c.Deep = "classroom.html", true }, "-deep"},
+		{"Empty Chromium flag", func(c *Config) { c.ChromeFlags = []string{"--"} }, "-chrome-flag"},
+		{"Chromium flag with spaces", func(c *Config) { c.NoChromeFlags = []string{"no sandbox"} }, "-no-chrome-flag"},
+		{"Bad max filesize", func(c *Config) { c.MaxFilesize = "500MB" }, "invalid -max-filesize"},
+		{"Too many tabs", func(c *Config) { c.Tabs = maxTabs + 1 }, "-tabs"},
+		{"Empty filename template", func(c *Config) { c.FilenameTemplate = " " }, "-filename-template"},
+		{"Unsupported recode format", func(c *Config) { c.Recode = "avi" }, "-recode"},
+		{"Zero login wait", func(c *Config) { c.LoginWait = 0 }, "-login-wait"},
+		{"Unknown platform", func(c *Config) { c.Platforms = "loom,vimeo" }, "-platforms"},
+		{"Auth token and cookies", func(c *Config) { c.AuthToken, c.CookiesFile = "abc", "cookies.json" }, "-auth-token"},
+		{"Check auth without URL", func(c *Config) {
+			c.CheckAuth, c.SkoolURL, c.Email, c.Password, c.CookiesFile = true, "", "", "", "cookies.json"
+		}, ""},
+		{"Check auth with email", func(c *Config) { c.CheckAuth = true }, "-check-auth"},
+		{"Print URLs and JSON", func(c *Config) { c.PrintURLs, c.JSON = true, true }, "-print-urls"},
+		{"Several proxies", func(c *Config) { c.Proxy = "socks5://10.0.0.1:1080, http://10.0.0.2:8080" }, ""},
+		{"One bad proxy of several", func(c *Config) { c.Proxy = "socks5://10.0.0.1:1080,ftp://10.0.0.2" }, "unsupported proxy scheme"},
+		{"List formats and list output", func(c *Config) { c.ListFormats, c.ListOutput = true, true }, "-list-formats"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid()
+			tt.modify(&config)
+			err := validateConfig(&config)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRun_ReturnsConfigErrors(t *testing.T) {
+	if err := Run(Config{}); !errors.Is(err, ErrMissingURL) {
+		t.Errorf("Expected ErrMissingURL, got %v", err)
+	}
+
+	err := Run(Config{EncryptCookies: filepath.Join(t.TempDir(), "cookies.enc")})
+	if err == nil || !strings.Contains(err.Error(), "-encrypt-cookies requires") {
+		t.Errorf("Expected an -encrypt-cookies error, got %v", err)
+	}
+}
+
+func TestRun_CookieModesValidateCookieFlags(t *testing.T) {
+	cookiesFile := filepath.Join(t.TempDir(), "cookies.txt")
+	content := "# Netscape HTTP Cookie File\n.skool.com\tTRUE\t/\tTRUE\t1800000000\tauth_token\tabc\n"
+	if err := os.WriteFile(cookiesFile, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	modes := []struct {
+		name  string
+		apply func(*Config)
+	}{
+		{"-export-cookies-json", func(c *Config) { c.ExportCookiesJSON = filepath.Join(t.TempDir(), "cookies.json") }},
+		{"-validate-cookies", func(c *Config) { c.ValidateCookies = true }},
+		{"-encrypt-cookies", func(c *Config) {
+			c.EncryptCookies = filepath.Join(t.TempDir(), "cookies.enc")
+			c.CookiesPassword = "secret"
+		}},
+	}
+	flags := []struct {
+		name  string
+		apply func(*Config)
+		want  string
+	}{
+		{"-cookies-format", func(c *Config) { c.CookiesFormat = "xml" }, "unknown -cookies-format"},
+		{"-cookies-samesite-zero", func(c *Config) { c.SameSiteZero = "lax" }, "unknown -cookies-samesite-zero"},
+	}
+
+	for _, mode := range modes {
+		for _, flag := range flags {
+			t.Run(mode.name+" "+flag.name, func(t *testing.T) {
+				config := Config{CookiesFile: cookiesFile}
+				mode.apply(&config)
+				flag.apply(&config)
+				if err := Run(config); err == nil || !strings.Contains(err.Error(), flag.want) {
+					t.Errorf("Run() error = %v, want %q", err, flag.want)
+				}
+			})
+		}
+	}
+}
+
+func TestExtractVideos_ValidatesConfig(t *testing.T) {
+	if _, err := ExtractVideos(context.Background(), Config{}); !errors.Is(err, ErrMissingURL) {
+		t.Errorf("Expected ErrMissingURL, got %v", err)
+	}
+}
+
+func TestPrepareYtDlpCookies_KeepTemp(t *testing.T) {
+	jsonFile := filepath.Join(t.TempDir(), "cookies.json")
+	content := `[{"host": ".skool.com", "name": "auth_token", "value": "abc", "path": "/", "expiry": 1700000000}]`
+	if err := os.WriteFile(jsonFile, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, keep := range []bool{false, true} {
+		tmpFile, cleanup, err := prepareYtDlpCookies(Config{CookiesFile: jsonFile, KeepTemp: keep})
+		if err != nil {
+			t.Fatalf("prepareYtDlpCookies() error = %v", err)
+		}
+		cleanup()
+
+		_, err = os.Stat(tmpFile)
+		if keep && err != nil {
+			t.Errorf("Expected -keep-temp to keep %s, got %v", tmpFile, err)
+		}
+		if !keep && !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed, got %v", tmpFile, err)
+		}
+		_ = os.Remove(tmpFile)
+	}
+}
+
+func TestPrintVideoURLs(t *testing.T) {
+	videos := []VideoEntry{
+		{URL: "https://www.loom.com/share/abc123", Title: "Intro"},
+		{URL: "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+	}
+
+	var buf bytes.Buffer
+	if err := printVideoURLs(&buf, videos); err != nil {
+		t.Fatalf("printVideoURLs() error = %v", err)
+	}
+	want := "https://www.loom.com/share/abc123\nhttps://www.youtube.com/watch?v=dQw4w9WgXcQ\n"
+	if got := buf.String(); got != want {
+		t.Errorf("printVideoURLs() wrote %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,101 @@
+package skool
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// skoolHost is the canonical host used when rebuilding Skool URLs
+const skoolHost = "www.skool.com"
+
+// skoolURL holds the parts of a Skool URL that identify a community and classroom
+type skoolURL struct {
+	Community   string // community slug, e.g. "my-school"
+	IsClassroom bool   // whether the path points into /classroom
+	Course      string // course ID from /classroom/<course>, empty for the classroom index
+	Lesson      string // lesson or set ID from ?md=
+}
+
+// isSkoolHost reports whether host is skool.com or one of its subdomains,
+// ignoring a port and the leading dot used by domain cookies
+func isSkoolHost(host string) bool {
+	host = strings.ToLower(strings.TrimPrefix(host, "."))
+	if h, _, found := strings.Cut(host, ":"); found {
+		host = h
+	}
+	return host == "skool.com" || strings.HasSuffix(host, ".skool.com")
+}
+
+// parseSkoolURL extracts the community slug and classroom path from any Skool
+// URL, tolerating a missing scheme, trailing slashes, query parameters and
+// fragments
+func parseSkoolURL(raw string) (skoolURL, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return skoolURL{}, fmt.Errorf("invalid URL %q: %v", raw, err)
+	}
+	if !isSkoolHost(u.Host) {
+		return skoolURL{}, fmt.Errorf("not a skool.com URL: %s", u.Host)
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	if len(segments) == 0 {
+		return skoolURL{}, fmt.Errorf("URL has no community: %s", raw)
+	}
+
+	parsed := skoolURL{
+		Community: segments[0],
+		Lesson:    u.Query().Get(lessonQueryParam),
+	}
+	if len(segments) > 1 && segments[1] == "classroom" {
+		parsed.IsClassroom = true
+		if len(segments) > 2 {
+			parsed.Course = segments[2]
+		}
+	}
+
+	return parsed, nil
+}
+
+// validateSkoolURL checks that raw is a Skool URL and returns it in canonical
+// form: https://www.skool.com host, no trailing slash, and only the lesson
+// query parameter kept
+func validateSkoolURL(raw string) (string, error) {
+	parsed, err := parseSkoolURL(raw)
+	if err != nil {
+		return "", err
+	}
+	if !parsed.IsClassroom {
+		return parsed.CommunityURL(), nil
+	}
+	if parsed.Lesson != "" {
+		return lessonURL(parsed.ClassroomURL(), parsed.Lesson), nil
+	}
+	return parsed.ClassroomURL(), nil
+}
+
+// CommunityURL returns the community's home URL
+func (s skoolURL) CommunityURL() string {
+	return "https://" + skoolHost + "/" + s.Community
+}
+
+// ClassroomURL returns the canonical classroom (or course) URL without any
+// lesson selection
+func (s skoolURL) ClassroomURL() string {
+	classroom := s.CommunityURL() + "/classroom"
+	if s.Course != "" {
+		classroom += "/" + s.Course
+	}
+	return classroom
+}
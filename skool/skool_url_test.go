@@ -0,0 +1,183 @@
+package skool
+
+import "testing"
+
+func TestParseSkoolURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected skoolURL
+	}{
+		{
+			name:     "Classroom course",
+			raw:      "https://www.skool.com/my-school/classroom/abc123",
+			expected: skoolURL{Community: "my-school", IsClassroom: true, Course: "abc123"},
+		},
+		{
+			name:     "Trailing slash",
+			raw:      "https://www.skool.com/my-school/classroom/abc123/",
+			expected: skoolURL{Community: "my-school", IsClassroom: true, Course: "abc123"},
+		},
+		{
+			name:     "Lesson query and fragment",
+			raw:      "https://www.skool.com/my-school/classroom/abc123?md=lesson42&utm_source=x#comments",
+			expected: skoolURL{Community: "my-school", IsClassroom: true, Course: "abc123", Lesson: "lesson42"},
+		},
+		{
+			name:     "Classroom index",
+			raw:      "https://skool.com/my-school/classroom",
+			expected: skoolURL{Community: "my-school", IsClassroom: true},
+		},
+		{
+			name:     "No scheme",
+			raw:      "skool.com/my-school/classroom/abc123",
+			expected: skoolURL{Community: "my-school", IsClassroom: true, Course: "abc123"},
+		},
+		{
+			name:     "Community root",
+			raw:      "https://www.skool.com/my-school",
+			expected: skoolURL{Community: "my-school"},
+		},
+		{
+			name:     "About page",
+			raw:      "https://www.skool.com/my-school/about?ref=abc",
+			expected: skoolURL{Community: "my-school"},
+		},
+		{
+			name:     "Uppercase host",
+			raw:      "HTTPS://WWW.SKOOL.COM/my-school/classroom/abc123",
+			expected: skoolURL{Community: "my-school", IsClassroom: true, Course: "abc123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSkoolURL(tt.raw)
+			if err != nil {
+				t.Fatalf("parseSkoolURL(%q) unexpected error: %v", tt.raw, err)
+			}
+			if got != tt.expected {
+				t.Errorf("parseSkoolURL(%q) = %+v, want %+v", tt.raw, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseSkoolURL_Invalid(t *testing.T) {
+	tests := []string{
+		"https://www.example.com/my-school/classroom/abc123",
+		"https://skool.com.evil.com/my-school",
+		"https://www.skool.com/",
+		"https://www.skool.com",
+	}
+
+	for _, raw := range tests {
+		t.Run(raw, func(t *testing.T) {
+			if _, err := parseSkoolURL(raw); err == nil {
+				t.Errorf("parseSkoolURL(%q) expected error, got nil", raw)
+			}
+		})
+	}
+}
+
+func TestValidateSkoolURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "Canonical classroom",
+			raw:      "https://www.skool.com/my-school/classroom/abc123",
+			expected: "https://www.skool.com/my-school/classroom/abc123",
+		},
+		{
+			name:     "Trailing slash and tracking params",
+			raw:      "https://skool.com/my-school/classroom/abc123/?utm_source=email&fbclid=x",
+			expected: "https://www.skool.com/my-school/classroom/abc123",
+		},
+		{
+			name:     "Lesson is kept",
+			raw:      "skool.com/my-school/classroom/abc123?ref=x&md=lesson42#top",
+			expected: "https://www.skool.com/my-school/classroom/abc123?md=lesson42",
+		},
+		{
+			name:     "Classroom index",
+			raw:      "https://www.skool.com/my-school/classroom/",
+			expected: "https://www.skool.com/my-school/classroom",
+		},
+		{
+			name:     "Community root",
+			raw:      "https://www.skool.com/my-school/",
+			expected: "https://www.skool.com/my-school",
+		},
+		{
+			name:     "About page",
+			raw:      "https://www.skool.com/my-school/about",
+			expected: "https://www.skool.com/my-school",
+		},
+		{
+			name:    "Non-skool host",
+			raw:     "https://www.loom.com/share/abc123",
+			wantErr: true,
+		},
+		{
+			name:    "Empty",
+			raw:     "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := validateSkoolURL(tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("validateSkoolURL(%q) expected error, got %q", tt.raw, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validateSkoolURL(%q) unexpected error: %v", tt.raw, err)
+			}
+			if got != tt.expected {
+				t.Errorf("validateSkoolURL(%q) = %q, want %q", tt.raw, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSkoolURL_Derived(t *testing.T) {
+	parsed := skoolURL{Community: "my-school", IsClassroom: true, Course: "abc123", Lesson: "lesson42"}
+
+	if got := parsed.CommunityURL(); got != "https://www.skool.com/my-school" {
+		t.Errorf("CommunityURL() = %v", got)
+	}
+	if got := parsed.ClassroomURL(); got != "https://www.skool.com/my-school/classroom/abc123" {
+		t.Errorf("ClassroomURL() = %v", got)
+	}
+}
+
+func TestIsSkoolHost(t *testing.T) {
+	tests := []struct {
+		host     string
+		expected bool
+	}{
+		{host: "skool.com", expected: true},
+		{host: ".skool.com", expected: true},
+		{host: "www.skool.com", expected: true},
+		{host: "www.skool.com:443", expected: true},
+		{host: "notskool.com", expected: false},
+		{host: "skool.com.evil.com", expected: false},
+		{host: "loom.com", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := isSkoolHost(tt.host); got != tt.expected {
+				t.Errorf("isSkoolHost(%q) = %v, want %v", tt.host, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package skool
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// stateFileName is the state file kept in the base output directory
+const stateFileName = ".state.json"
+
+// StateStore remembers which videos of each classroom were downloaded, so a
+// later run can skip them even after the files were moved or renamed. Videos
+// are identified by videoKey, classrooms by stateKey. It's safe for
+// concurrent use.
+type StateStore struct {
+	mu   sync.Mutex
+	path string
+
+	// Classrooms maps a classroom URL to its downloaded video keys and when
+	// each was downloaded
+	Classrooms map[string]map[string]time.Time `json:"classrooms"`
+}
+
+// loadStateStore reads the state file at path, starting empty when it
+// doesn't exist yet
+func loadStateStore(path string) (*StateStore, error) {
+	store := &StateStore{path: path, Classrooms: make(map[string]map[string]time.Time)}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, store); err != nil {
+		return nil, err
+	}
+	if store.Classrooms == nil {
+		store.Classrooms = make(map[string]map[string]time.Time)
+	}
+	return store, nil
+}
+
+// stateKey returns the classroom a URL belongs to, ignoring the lesson it
+// points at, so a whole course and a single lesson of it share their state
+func stateKey(rawURL string) string {
+	parsed, err := parseSkoolURL(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	if !parsed.IsClassroom {
+		return parsed.CommunityURL()
+	}
+	return parsed.ClassroomURL()
+}
+
+// IsDownloaded reports whether video was downloaded from classroom before
+func (s *StateStore) IsDownloaded(classroom string, video VideoEntry) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.Classrooms[stateKey(classroom)][video.Key()]
+	return ok
+}
+
+// MarkDownloaded records that video was downloaded from classroom
+func (s *StateStore) MarkDownloaded(classroom string, video VideoEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := stateKey(classroom)
+	if s.Classrooms[key] == nil {
+		s.Classrooms[key] = make(map[string]time.Time)
+	}
+	s.Classrooms[key][video.Key()] = time.Now().UTC()
+}
+
+// Save writes the state file, replacing it only once the new content is
+// fully written so an interrupted run can't leave it truncated
+func (s *StateStore) Save() error {
+	s.mu.Lock()
+	content, err := json.MarshalIndent(s, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), stateFileName+"-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err := tmp.Write(append(content, '\n')); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// filterDownloaded returns the videos of classroom that aren't in state yet,
+// along with how many were left out
+func filterDownloaded(videos []VideoEntry, state *StateStore, classroom string) ([]VideoEntry, int) {
+	var kept []VideoEntry
+	for _, v := range videos {
+		if !state.IsDownloaded(classroom, v) {
+			kept = append(kept, v)
+		}
+	}
+	return kept, len(videos) - len(kept)
+}
@@ -0,0 +1,119 @@
+package skool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestStateStore_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), stateFileName)
+	classroom := "https://www.skool.com/group/classroom/abc"
+
+	store, err := loadStateStore(path)
+	if err != nil {
+		t.Fatalf("loadStateStore() on a missing file error = %v", err)
+	}
+	store.MarkDownloaded(classroom, VideoEntry{URL: "https://www.loom.com/share/abc123"})
+	if err := store.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := loadStateStore(path)
+	if err != nil {
+		t.Fatalf("loadStateStore() error = %v", err)
+	}
+
+	// The same video by another link, from a lesson URL of the same course
+	embed := VideoEntry{URL: "https://loom.com/embed/abc123?hideEmbedTopBar=true"}
+	if !loaded.IsDownloaded(classroom+"?md=lesson1", embed) {
+		t.Error("Expected the video to be downloaded after a round trip")
+	}
+	if loaded.IsDownloaded("https://www.skool.com/group/classroom/other", embed) {
+		t.Error("Expected another classroom not to share the state")
+	}
+	if loaded.IsDownloaded(classroom, VideoEntry{URL: "https://www.loom.com/share/def456"}) {
+		t.Error("Expected an unknown video not to be downloaded")
+	}
+}
+
+func TestLoadStateStore_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), stateFileName)
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadStateStore(path); err == nil {
+		t.Error("Expected an error for a corrupt state file")
+	}
+}
+
+func TestStateStore_ConcurrentUpdates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), stateFileName)
+	store, err := loadStateStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	classroom := "https://www.skool.com/group/classroom/abc"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			video := VideoEntry{URL: fmt.Sprintf("https://www.loom.com/share/video%d", i)}
+			store.MarkDownloaded(classroom, video)
+			_ = store.IsDownloaded(classroom, video)
+			if err := store.Save(); err != nil {
+				t.Errorf("Save() error = %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if err := store.Save(); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadStateStore(path)
+	if err != nil {
+		t.Fatalf("loadStateStore() error = %v", err)
+	}
+	if got := len(loaded.Classrooms[classroom]); got != 20 {
+		t.Errorf("Expected 20 downloaded videos, got %d", got)
+	}
+}
+
+func TestFilterDownloaded(t *testing.T) {
+	store, err := loadStateStore(filepath.Join(t.TempDir(), stateFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	classroom := "https://www.skool.com/group/classroom/abc"
+	store.MarkDownloaded(classroom, VideoEntry{URL: "https://www.loom.com/share/a"})
+
+	videos := []VideoEntry{{URL: "https://www.loom.com/share/a"}, {URL: "https://www.loom.com/share/b"}}
+	kept, done := filterDownloaded(videos, store, classroom)
+	if done != 1 || len(kept) != 1 || kept[0].URL != "https://www.loom.com/share/b" {
+		t.Errorf("filterDownloaded() = %v, %d, want only share/b", kept, done)
+	}
+}
+
+func TestFilterDownloaded_SameKeyAsDedupe(t *testing.T) {
+	store, err := loadStateStore(filepath.Join(t.TempDir(), stateFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	classroom := "https://www.skool.com/group/classroom/abc"
+	// Recorded from the regex fallback, found again in the course tree
+	store.MarkDownloaded(classroom, VideoEntry{URL: "https://cdn.example.com/lesson.mp4"})
+	store.MarkDownloaded(classroom, VideoEntry{URL: "https://www.loom.com/embed/abc123"})
+
+	videos := []VideoEntry{
+		newVideoEntry("https://www.cdn.example.com/lesson.mp4", platformDirect),
+		newVideoEntry("https://www.loom.com/share/abc123", platformLoom),
+	}
+	if kept, done := filterDownloaded(videos, store, classroom); done != 2 {
+		t.Errorf("filterDownloaded() kept %v, want both recognized as downloaded", kept)
+	}
+}
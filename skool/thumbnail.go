@@ -0,0 +1,105 @@
+package skool
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// thumbnailFetchTimeout bounds downloading a thumbnail from the course tree
+const thumbnailFetchTimeout = 30 * time.Second
+
+// lessonThumbnailKeys are the lesson metadata fields that may hold the
+// video's poster image
+var lessonThumbnailKeys = []string{"videoThumbnail", "thumbnail"}
+
+// thumbnailExtensions are the image types yt-dlp saves thumbnails as
+var thumbnailExtensions = []string{".jpg", ".jpeg", ".png", ".webp"}
+
+// lessonThumbnail returns the lesson's poster image URL, or "" if the course
+// tree doesn't have one
+func lessonThumbnail(metadata map[string]interface{}) string {
+	for _, key := range lessonThumbnailKeys {
+		if link, ok := metadata[key].(string); ok {
+			link = strings.TrimSpace(link)
+			if u, err := url.Parse(link); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+				return link
+			}
+		}
+	}
+	return ""
+}
+
+// thumbnailBase returns the path of a video's thumbnail without the
+// extension, which yt-dlp names after the video
+func thumbnailBase(videoFile string) string {
+	return strings.TrimSuffix(videoFile, filepath.Ext(videoFile))
+}
+
+// hasThumbnail reports whether an image named after the video exists
+func hasThumbnail(videoFile string) bool {
+	base := thumbnailBase(videoFile)
+	for _, ext := range thumbnailExtensions {
+		if _, err := os.Stat(base + ext); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// downloadThumbnail saves the image at thumbURL next to videoFile, keeping
+// the URL's image extension, and returns where it was saved
+func downloadThumbnail(thumbURL, videoFile string) (string, error) {
+	ext := ".jpg"
+	if u, err := url.Parse(thumbURL); err == nil {
+		for _, known := range thumbnailExtensions {
+			if strings.EqualFold(path.Ext(u.Path), known) {
+				ext = known
+			}
+		}
+	}
+
+	client := &http.Client{Timeout: thumbnailFetchTimeout}
+	resp, err := client.Get(thumbURL)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("thumbnail request returned %s", resp.Status)
+	}
+
+	target := thumbnailBase(videoFile) + ext
+	file, err := os.Create(target)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(file, resp.Body); err != nil {
+		_ = file.Close()
+		_ = os.Remove(target)
+		return "", err
+	}
+	return target, file.Close()
+}
+
+// ensureThumbnail falls back to the course tree's thumbnail when yt-dlp
+// didn't save one for -write-thumbnail. A missing thumbnail is only a warning.
+func ensureThumbnail(video VideoEntry, videoFile string) {
+	if videoFile == "" || video.Thumbnail == "" || hasThumbnail(videoFile) {
+		return
+	}
+	path, err := downloadThumbnail(video.Thumbnail, videoFile)
+	if err != nil {
+		console.Warningf("Failed to download the lesson thumbnail: %v", err)
+		return
+	}
+	console.Debug("Saved lesson thumbnail from the course data:", path)
+}
@@ -0,0 +1,65 @@
+package skool
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLessonThumbnail(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]interface{}
+		want     string
+	}{
+		{"Video thumbnail", map[string]interface{}{"videoThumbnail": " https://assets.skool.com/t/abc.jpg "}, "https://assets.skool.com/t/abc.jpg"},
+		{"Fallback key", map[string]interface{}{"thumbnail": "https://assets.skool.com/t/abc.png"}, "https://assets.skool.com/t/abc.png"},
+		{"Not a URL", map[string]interface{}{"videoThumbnail": "abc.jpg"}, ""},
+		{"Missing", map[string]interface{}{"videoLink": "https://www.loom.com/share/abc"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lessonThumbnail(tt.metadata); got != tt.want {
+				t.Errorf("lessonThumbnail() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnsureThumbnail(t *testing.T) {
+	previous := console
+	console = newLogger(levelQuiet, io.Discard)
+	defer func() {
+		console = previous
+	}()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("png bytes"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	video := VideoEntry{URL: "https://www.loom.com/share/abc", Thumbnail: server.URL + "/poster.PNG?v=2"}
+
+	// yt-dlp already saved one, so nothing is downloaded
+	saved := filepath.Join(dir, "Saved.mp4")
+	if err := os.WriteFile(filepath.Join(dir, "Saved.webp"), []byte("webp"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ensureThumbnail(video, saved)
+	if _, err := os.Stat(filepath.Join(dir, "Saved.png")); err == nil {
+		t.Error("Expected no fallback thumbnail when yt-dlp saved one")
+	}
+
+	// Otherwise the course tree's image is saved next to the video
+	missing := filepath.Join(dir, "Missing.mp4")
+	ensureThumbnail(video, missing)
+	content, err := os.ReadFile(filepath.Join(dir, "Missing.png"))
+	if err != nil || string(content) != "png bytes" {
+		t.Errorf("Expected the fallback thumbnail next to the video, got %q, %v", content, err)
+	}
+}
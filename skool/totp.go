@@ -0,0 +1,93 @@
+package skool
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chromedp/chromedp"
+)
+
+// totpSecretEnv is the environment variable read when -totp-secret isn't set
+const totpSecretEnv = "SKOOL_TOTP_SECRET"
+
+// RFC 6238 defaults used by authenticator apps
+const (
+	totpPeriod = 30 * time.Second
+	totpDigits = 6
+)
+
+// Selectors for the two-factor code prompt shown after submitting credentials
+const (
+	twoFactorInputSelector  = `input[autocomplete="one-time-code"], input[name*="code" i], input[placeholder*="code" i]`
+	twoFactorSubmitSelector = `button[type="submit"]`
+)
+
+// totpCode computes the RFC 6238 time-based one-time password for a base32
+// secret (as shown by "can't scan the QR code" setup screens) at time t
+func totpCode(secret string, t time.Time, digits int) (string, error) {
+	normalized := strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(secret))
+	normalized = strings.TrimRight(normalized, "=")
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(normalized)
+	if err != nil {
+		return "", fmt.Errorf("invalid TOTP secret, expected base32: %v", err)
+	}
+
+	counter := uint64(t.Unix() / int64(totpPeriod/time.Second))
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation (RFC 4226 section 5.3)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%mod), nil
+}
+
+// completeTwoFactor fills in the 2FA prompt if the page shows one, reporting
+// whether a code was submitted. Accounts without 2FA are skipped gracefully.
+func completeTwoFactor(ctx context.Context, secret string) (bool, error) {
+	var hasPrompt bool
+	if err := chromedp.Run(ctx, chromedp.Evaluate(
+		fmt.Sprintf(`document.querySelector(%q) !== null`, twoFactorInputSelector), &hasPrompt,
+	)); err != nil {
+		return false, fmt.Errorf("failed to check for two-factor prompt: %v", err)
+	}
+	if !hasPrompt {
+		return false, nil
+	}
+
+	if secret == "" {
+		return false, fmt.Errorf("two-factor authentication is enabled for this account, provide your authenticator secret with -totp-secret or %s", totpSecretEnv)
+	}
+
+	code, err := totpCode(secret, time.Now(), totpDigits)
+	if err != nil {
+		return false, err
+	}
+
+	console.Auth("Two-factor prompt detected, submitting TOTP code...")
+	if err := chromedp.Run(ctx, chromedp.Tasks{
+		chromedp.SendKeys(twoFactorInputSelector, code, chromedp.ByQuery),
+		chromedp.Click(twoFactorSubmitSelector, chromedp.ByQuery),
+		waitReady(loginDoneJS, loginWaitTime),
+	}); err != nil {
+		return false, fmt.Errorf("failed to submit two-factor code: %v", err)
+	}
+
+	return true, nil
+}
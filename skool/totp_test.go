@@ -0,0 +1,52 @@
+package skool
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 test key from RFC 6238 appendix B ("12345678901234567890") in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{unix: 59, expected: "94287082"},
+		{unix: 1111111109, expected: "07081804"},
+		{unix: 1111111111, expected: "14050471"},
+		{unix: 1234567890, expected: "89005924"},
+		{unix: 2000000000, expected: "69279037"},
+		{unix: 20000000000, expected: "65353130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			code, err := totpCode(rfc6238Secret, time.Unix(tt.unix, 0), 8)
+			if err != nil {
+				t.Fatalf("totpCode() error = %v", err)
+			}
+			if code != tt.expected {
+				t.Errorf("totpCode(%d) = %s, want %s", tt.unix, code, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTOTPCode_SixDigitsAndFormatting(t *testing.T) {
+	// Authenticator apps show secrets lowercase and grouped with spaces
+	code, err := totpCode("gezd gnbv gy3t qojq gezd gnbv gy3t qojq", time.Unix(59, 0), totpDigits)
+	if err != nil {
+		t.Fatalf("totpCode() error = %v", err)
+	}
+	if code != "287082" {
+		t.Errorf("totpCode() = %s, want 287082", code)
+	}
+}
+
+func TestTOTPCode_InvalidSecret(t *testing.T) {
+	if _, err := totpCode("not-base32!", time.Unix(59, 0), totpDigits); err == nil {
+		t.Error("Expected error for invalid secret, got nil")
+	}
+}
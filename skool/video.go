@@ -0,0 +1,161 @@
+package skool
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// videoPlatform is where a video is hosted
+type videoPlatform string
+
+// Platforms a video can be hosted on
+const (
+	platformLoom    videoPlatform = "loom"
+	platformYouTube videoPlatform = "youtube"
+	platformDrive   videoPlatform = "drive"
+	platformDirect  videoPlatform = "direct"
+)
+
+// VideoEntry is a video found in a classroom
+type VideoEntry struct {
+	URL       string
+	Title     string        // lesson title, empty when the video was found by the regex fallback
+	Module    string        // title of the set (module) the lesson is in, if any
+	Platform  videoPlatform // where the video is hosted
+	ID        string        // the platform's video ID, or host and path for direct links
+	Added     time.Time     // when the lesson was added, zero if __NEXT_DATA__ didn't say
+	MediaURL  string        // stream captured by -capture-network, downloaded instead of URL
+	Thumbnail string        // poster image from the course tree, used when yt-dlp has none
+	Position  []int         // 1-based index of the lesson and each enclosing set in the course tree
+	Number    string        // -number-files prefix built from Position, e.g. "01.03"
+}
+
+// driveFileRegex matches a Google Drive file link and captures its ID
+var driveFileRegex = regexp.MustCompile(`https?://drive\.google\.com/file/d/([a-zA-Z0-9_-]+)`)
+
+// directVideoExtensions are the file extensions treated as direct video links
+var directVideoExtensions = []string{".mp4", ".m4v", ".mov", ".webm", ".mkv"}
+
+// normalizeDriveURL returns the /view form of a Google Drive file link, which
+// yt-dlp's Drive extractor accepts, or "" if link isn't a Drive file
+func normalizeDriveURL(link string) string {
+	match := driveFileRegex.FindStringSubmatch(link)
+	if match == nil {
+		return ""
+	}
+	return "https://drive.google.com/file/d/" + match[1] + "/view"
+}
+
+// isDirectVideoURL reports whether link is an http(s) URL to a video file
+func isDirectVideoURL(link string) bool {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return false
+	}
+	ext := strings.ToLower(path.Ext(u.Path))
+	for _, videoExt := range directVideoExtensions {
+		if ext == videoExt {
+			return true
+		}
+	}
+	return false
+}
+
+// videoIDRegexes capture the video ID from the URL forms of each platform
+var videoIDRegexes = []struct {
+	platform videoPlatform
+	re       *regexp.Regexp
+}{
+	{platformLoom, regexp.MustCompile(`loom\.com/(?:share|embed)/([a-zA-Z0-9_-]+)`)},
+	{platformYouTube, regexp.MustCompile(`(?:youtube\.com/watch\?v=|youtu\.be/|youtube\.com/embed/|youtube\.com/v/)([a-zA-Z0-9_-]{11})`)},
+	{platformDrive, driveFileRegex},
+}
+
+// videoKey identifies the video behind link, so that share and embed links,
+// www and bare hosts, and http and https all map to the same key. Links to
+// other hosts are keyed by host and path without the scheme or a www prefix.
+func videoKey(link string) string {
+	link = strings.TrimSpace(link)
+	for _, p := range videoIDRegexes {
+		if match := p.re.FindStringSubmatch(link); match != nil {
+			return string(p.platform) + ":" + match[1]
+		}
+	}
+
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return link
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	key := host + u.EscapedPath()
+	if u.RawQuery != "" {
+		key += "?" + u.RawQuery
+	}
+	return key
+}
+
+// newVideoEntry returns the video at link on platform, with its ID filled in
+func newVideoEntry(link string, platform videoPlatform) VideoEntry {
+	return VideoEntry{URL: link, Platform: platform, ID: videoID(platform, link)}
+}
+
+// videoID returns the ID of the video at link on platform. Direct links have
+// no ID of their own, so they're identified by host and path like in videoKey.
+func videoID(platform videoPlatform, link string) string {
+	for _, p := range videoIDRegexes {
+		if p.platform != platform {
+			continue
+		}
+		if match := p.re.FindStringSubmatch(link); match != nil {
+			return match[1]
+		}
+	}
+	return videoKey(link)
+}
+
+// Key identifies the video for deduplication and the state file by platform
+// and ID. Direct links and entries built without an ID use videoKey, which
+// gives the same key for the same link either way and matches the keys state
+// files were written with.
+func (v VideoEntry) Key() string {
+	if v.Platform != "" && v.Platform != platformDirect && v.ID != "" {
+		return string(v.Platform) + ":" + v.ID
+	}
+	return videoKey(v.URL)
+}
+
+// dedupeVideos drops videos whose Key was already seen, keeping the first
+// occurrence, and returns how many were dropped
+func dedupeVideos(videos []VideoEntry) ([]VideoEntry, int) {
+	seen := make(map[string]bool)
+	var result []VideoEntry
+	for _, v := range videos {
+		key := v.Key()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, v)
+	}
+	return result, len(videos) - len(result)
+}
+
+// videoURLs flattens videos to their URLs
+func videoURLs(videos []VideoEntry) []string {
+	var urls []string
+	for _, v := range videos {
+		urls = append(urls, v.URL)
+	}
+	return urls
+}
+
+// limitVideos returns at most the first n videos; n <= 0 means no limit
+func limitVideos(videos []VideoEntry, n int) []VideoEntry {
+	if n <= 0 || len(videos) <= n {
+		return videos
+	}
+	return videos[:n]
+}
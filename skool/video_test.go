@@ -0,0 +1,262 @@
+package skool
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLimitVideos(t *testing.T) {
+	videos := []VideoEntry{
+		{URL: "https://www.loom.com/share/a"},
+		{URL: "https://www.loom.com/share/b"},
+		{URL: "https://www.loom.com/share/c"},
+	}
+
+	tests := []struct {
+		name     string
+		limit    int
+		expected []string
+	}{
+		{name: "Below count", limit: 2, expected: []string{"https://www.loom.com/share/a", "https://www.loom.com/share/b"}},
+		{name: "Equal to count", limit: 3, expected: videoURLs(videos)},
+		{name: "Above count", limit: 10, expected: videoURLs(videos)},
+		{name: "Zero means no limit", limit: 0, expected: videoURLs(videos)},
+		{name: "Negative means no limit", limit: -1, expected: videoURLs(videos)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := videoURLs(limitVideos(videos, tt.limit)); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("limitVideos(%d) = %v, want %v", tt.limit, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestVideoURLs(t *testing.T) {
+	if got := videoURLs(nil); got != nil {
+		t.Errorf("videoURLs(nil) = %v, want nil", got)
+	}
+
+	videos := []VideoEntry{{URL: "https://www.loom.com/share/a", Title: "Intro"}, {URL: "https://youtu.be/x"}}
+	want := []string{"https://www.loom.com/share/a", "https://youtu.be/x"}
+	if got := videoURLs(videos); !reflect.DeepEqual(got, want) {
+		t.Errorf("videoURLs() = %v, want %v", got, want)
+	}
+}
+
+func TestNormalizeDriveURL(t *testing.T) {
+	tests := map[string]string{
+		"https://drive.google.com/file/d/1AbC_dEf-GhI/view?usp=sharing": "https://drive.google.com/file/d/1AbC_dEf-GhI/view",
+		"https://drive.google.com/file/d/1AbC_dEf-GhI/preview":          "https://drive.google.com/file/d/1AbC_dEf-GhI/view",
+		"https://drive.google.com/drive/folders/1AbC":                   "",
+		"https://www.loom.com/share/abc123":                             "",
+	}
+
+	for link, expected := range tests {
+		if got := normalizeDriveURL(link); got != expected {
+			t.Errorf("normalizeDriveURL(%q) = %q, want %q", link, got, expected)
+		}
+	}
+}
+
+func TestIsDirectVideoURL(t *testing.T) {
+	tests := map[string]bool{
+		"https://cdn.example.com/lesson.mp4":         true,
+		"https://cdn.example.com/lesson.MOV?sig=123": true,
+		"http://example.com/a/b/c.webm":              true,
+		"https://cdn.example.com/lesson.mp4.html":    false,
+		"https://cdn.example.com/poster.jpg":         false,
+		"ftp://files.example.com/lesson.mp4":         false,
+		"/relative/lesson.mp4":                       false,
+		"https://www.loom.com/share/abc123":          false,
+	}
+
+	for link, expected := range tests {
+		if got := isDirectVideoURL(link); got != expected {
+			t.Errorf("isDirectVideoURL(%q) = %v, want %v", link, got, expected)
+		}
+	}
+}
+
+func TestWalkCourseVideos_Platforms(t *testing.T) {
+	lesson := func(title, link string) interface{} {
+		return map[string]interface{}{
+			"course": map[string]interface{}{
+				"unitType": "module",
+				"metadata": map[string]interface{}{"title": title, "videoLink": link},
+			},
+		}
+	}
+	course := map[string]interface{}{
+		"children": []interface{}{
+			lesson("Loom", "https://www.loom.com/share/abc123"),
+			lesson("YouTube", "https://youtu.be/dQw4w9WgXcQ"),
+			lesson("Drive", "https://drive.google.com/file/d/1AbC_dEf-GhI/view?usp=sharing"),
+			lesson("Direct", "https://cdn.example.com/lesson.mp4"),
+			lesson("Unsupported", "https://vimeo.com/123456"),
+		},
+	}
+
+	videos, skipped := walkCourseVideos(course, "", nil)
+	var got []string
+	for _, v := range videos {
+		got = append(got, v.Title+"="+string(v.Platform)+":"+v.ID)
+	}
+	expected := []string{"Loom=loom:abc123", "YouTube=youtube:dQw4w9WgXcQ", "Drive=drive:1AbC_dEf-GhI", "Direct=direct:cdn.example.com/lesson.mp4"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("walkCourseVideos() platforms = %v, want %v", got, expected)
+	}
+
+	wantSkipped := []unsupportedLesson{{Title: "Unsupported", Link: "https://vimeo.com/123456"}}
+	if !reflect.DeepEqual(skipped.Unsupported, wantSkipped) {
+		t.Errorf("walkCourseVideos() unsupported = %v, want %v", skipped.Unsupported, wantSkipped)
+	}
+}
+
+func TestVideoKey(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		same bool
+	}{
+		{"loom share vs embed", "https://www.loom.com/share/abc123", "https://www.loom.com/embed/abc123", true},
+		{"loom www vs bare host", "https://loom.com/share/abc123", "https://www.loom.com/share/abc123", true},
+		{"loom share with query", "https://www.loom.com/share/abc123?sid=x", "https://www.loom.com/share/abc123", true},
+		{"different loom videos", "https://www.loom.com/share/abc123", "https://www.loom.com/share/def456", false},
+		{"youtube watch vs short", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", "https://youtu.be/dQw4w9WgXcQ", true},
+		{"youtube www vs bare host", "https://youtube.com/embed/dQw4w9WgXcQ", "https://www.youtube.com/watch?v=dQw4w9WgXcQ", true},
+		{"drive view vs preview", "https://drive.google.com/file/d/1AbC_dEf/view", "https://drive.google.com/file/d/1AbC_dEf/preview", true},
+		{"direct www vs bare host", "https://www.example.com/videos/intro.mp4", "http://example.com/videos/intro.mp4", true},
+		{"direct different files", "https://example.com/videos/intro.mp4", "https://example.com/videos/outro.mp4", false},
+		{"loom vs youtube with same id", "https://www.loom.com/share/dQw4w9WgXcQ", "https://youtu.be/dQw4w9WgXcQ", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ka, kb := videoKey(tt.a), videoKey(tt.b)
+			if (ka == kb) != tt.same {
+				t.Errorf("videoKey(%q) = %q, videoKey(%q) = %q, want same = %v", tt.a, ka, tt.b, kb, tt.same)
+			}
+		})
+	}
+}
+
+func TestDedupeVideos(t *testing.T) {
+	videos := []VideoEntry{
+		{URL: "https://www.loom.com/share/abc123", Title: "Intro"},
+		{URL: "https://youtu.be/dQw4w9WgXcQ", Title: "Talk"},
+		{URL: "https://loom.com/embed/abc123", Title: "Intro again"},
+		{URL: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", Title: "Talk again"},
+	}
+	got, removed := dedupeVideos(videos)
+	if removed != 2 || len(got) != 2 {
+		t.Fatalf("dedupeVideos() kept %d and removed %d, want 2 and 2", len(got), removed)
+	}
+	if got[0].Title != "Intro" || got[1].Title != "Talk" {
+		t.Errorf("Expected the first occurrence of each video to be kept, got %+v", got)
+	}
+}
+
+func TestDedupeVideos_PlatformAndID(t *testing.T) {
+	videos := []VideoEntry{
+		newVideoEntry("https://www.loom.com/share/abc123", platformLoom),
+		// Same ID on another platform is a different video
+		{URL: "https://cdn.example.com/abc123", Platform: platformDirect, ID: "abc123"},
+		// Different links, but the same platform and ID
+		{URL: "https://www.loom.com/share/abc123?sid=1", Platform: platformLoom, ID: "abc123"},
+	}
+	got, removed := dedupeVideos(videos)
+	if removed != 1 || len(got) != 2 || got[1].Platform != platformDirect {
+		t.Errorf("dedupeVideos() = %+v, removed %d, want the Loom and direct videos", got, removed)
+	}
+}
+
+func TestVideoEntryKey_SameWithoutID(t *testing.T) {
+	links := []struct {
+		link     string
+		platform videoPlatform
+	}{
+		{"https://www.loom.com/share/abc123", platformLoom},
+		{"https://youtu.be/dQw4w9WgXcQ", platformYouTube},
+		{"https://drive.google.com/file/d/1AbC_dEf-GhI/view", platformDrive},
+		{"https://cdn.example.com/lesson.mp4", platformDirect},
+	}
+
+	// Entries from the regex fallback or older code paths have only a URL
+	for _, tt := range links {
+		withID, bare := newVideoEntry(tt.link, tt.platform), VideoEntry{URL: tt.link}
+		if withID.Key() != bare.Key() {
+			t.Errorf("Key() of %s = %q with an ID, %q without", tt.link, withID.Key(), bare.Key())
+		}
+	}
+}
+
+func TestNewVideoEntry(t *testing.T) {
+	tests := []struct {
+		link     string
+		platform videoPlatform
+		wantID   string
+		wantKey  string
+	}{
+		{"https://www.loom.com/share/abc123", platformLoom, "abc123", "loom:abc123"},
+		{"https://www.youtube.com/watch?v=dQw4w9WgXcQ", platformYouTube, "dQw4w9WgXcQ", "youtube:dQw4w9WgXcQ"},
+		{"https://drive.google.com/file/d/1AbC_dEf-GhI/view", platformDrive, "1AbC_dEf-GhI", "drive:1AbC_dEf-GhI"},
+		{"https://www.cdn.example.com/lesson.mp4?t=1", platformDirect, "cdn.example.com/lesson.mp4?t=1", "cdn.example.com/lesson.mp4?t=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.platform), func(t *testing.T) {
+			video := newVideoEntry(tt.link, tt.platform)
+			if video.URL != tt.link || video.Platform != tt.platform || video.ID != tt.wantID {
+				t.Errorf("newVideoEntry() = %+v, want ID %q", video, tt.wantID)
+			}
+			if key := video.Key(); key != tt.wantKey {
+				t.Errorf("Key() = %q, want %q", key, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestExtractVideos_DedupesShareAndEmbed(t *testing.T) {
+	html := `<a href="https://loom.com/share/abc123">x</a><iframe src="https://www.loom.com/embed/abc123"></iframe>`
+	if got, _ := extractVideos(html); len(got) != 1 {
+		t.Errorf("Expected one video for a share and embed link to the same Loom, got %+v", got)
+	}
+}
+
+func TestSkippedLessons_Add(t *testing.T) {
+	var skipped skippedLessons
+	skipped.add(skippedLessons{Pending: []string{"A"}, Unsupported: []unsupportedLesson{{"B", "https://vimeo.com/1"}}})
+	skipped.add(skippedLessons{Pending: []string{"A", "C"}, Unsupported: []unsupportedLesson{{"B", "https://vimeo.com/1"}}})
+
+	if !reflect.DeepEqual(skipped.Pending, []string{"A", "C"}) || len(skipped.Unsupported) != 1 {
+		t.Errorf("add() didn't merge without duplicates: %+v", skipped)
+	}
+}
+
+func TestWalkCourseVideos_LessonContent(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join(fixturesDir, "lesson_embeds.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := extractNextDataJSON(string(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	videos, _ := walkNextDataVideos(data)
+	var got []string
+	for _, v := range videos {
+		got = append(got, v.Title+"="+v.URL)
+	}
+	expected := []string{
+		"Welcome=https://www.loom.com/share/aaaa1111bbbb2222",
+		"Welcome (2)=https://www.youtube.com/watch?v=dQw4w9WgXcQ",
+		"Reading=https://www.loom.com/share/cccc3333dddd4444",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("walkNextDataVideos() = %v, want %v", got, expected)
+	}
+}
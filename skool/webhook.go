@@ -0,0 +1,76 @@
+package skool
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// webhookTimeout bounds the POST so a dead endpoint can't hang the end of a run
+const webhookTimeout = 10 * time.Second
+
+// webhookPayload is the JSON summary POSTed to -webhook when a run finishes
+type webhookPayload struct {
+	Status          string  `json:"status"`
+	Succeeded       int     `json:"succeeded"`
+	Failed          int     `json:"failed"`
+	Skipped         int     `json:"skipped"`
+	DurationSeconds float64 `json:"duration_seconds"`
+	OutputDir       string  `json:"output_dir"`
+	Error           string  `json:"error,omitempty"`
+}
+
+// newWebhookPayload summarizes a run from its report and the error Run returned
+func newWebhookPayload(report *downloadReport, outputDir string, duration time.Duration, err error) webhookPayload {
+	succeeded, failed, skipped := report.Counts()
+	payload := webhookPayload{
+		Status:          statusSucceeded,
+		Succeeded:       succeeded,
+		Failed:          failed,
+		Skipped:         skipped,
+		DurationSeconds: duration.Round(time.Millisecond).Seconds(),
+		OutputDir:       outputDir,
+	}
+	if err != nil {
+		payload.Status = statusFailed
+		payload.Error = err.Error()
+	}
+	return payload
+}
+
+// validateWebhook checks that raw is an http or https URL
+func validateWebhook(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("invalid -webhook URL %q: %v", raw, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid -webhook URL %q, use an http:// or https:// URL", raw)
+	}
+	return nil
+}
+
+// postWebhook sends payload to target as JSON
+func postWebhook(target string, payload webhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Timeout: webhookTimeout}
+	resp, err := client.Post(target, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
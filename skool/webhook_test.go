@@ -0,0 +1,82 @@
+package skool
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPostWebhook(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST, got %s", r.Method)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Expected JSON content type, got %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("Payload is not valid JSON: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	payload := newWebhookPayload(testReport(), "downloads/community/course", 1500*time.Millisecond, errors.New("scraping failed"))
+	if err := postWebhook(server.URL, payload); err != nil {
+		t.Fatalf("postWebhook() error = %v", err)
+	}
+
+	want := map[string]any{
+		"status":           "failed",
+		"succeeded":        float64(1),
+		"failed":           float64(1),
+		"skipped":          float64(1),
+		"duration_seconds": 1.5,
+		"output_dir":       "downloads/community/course",
+		"error":            "scraping failed",
+	}
+	if len(got) != len(want) {
+		t.Errorf("Payload has %d fields, want %d: %v", len(got), len(want), got)
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("Payload[%q] = %v, want %v", key, got[key], value)
+		}
+	}
+}
+
+func TestPostWebhook_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	payload := newWebhookPayload(&downloadReport{}, "downloads", time.Second, nil)
+	if payload.Status != statusSucceeded || payload.Error != "" {
+		t.Errorf("Unexpected payload for a successful run: %+v", payload)
+	}
+	if err := postWebhook(server.URL, payload); err == nil {
+		t.Error("Expected an error for a 500 response")
+	}
+}
+
+func TestValidateWebhook(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://hooks.example.com/notify", false},
+		{"http://localhost:8080/done", false},
+		{"ftp://example.com", true},
+		{"example.com/hook", true},
+		{"https://", true},
+	}
+	for _, tt := range tests {
+		if err := validateWebhook(tt.url); (err != nil) != tt.wantErr {
+			t.Errorf("validateWebhook(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package skool
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "Empty", input: "", expected: nil},
+		{name: "Whitespace only", input: "  \t ", expected: nil},
+		{name: "Plain words", input: "--concurrent-fragments 4  --throttled-rate 100K", expected: []string{"--concurrent-fragments", "4", "--throttled-rate", "100K"}},
+		{name: "Double quotes", input: `--match-filter "duration > 60"`, expected: []string{"--match-filter", "duration > 60"}},
+		{name: "Single quotes are literal", input: `-f 'best[height<=720]' --print '%(title)s \n'`, expected: []string{"-f", "best[height<=720]", "--print", `%(title)s \n`}},
+		{name: "Escapes", input: `--referer a\ b "say \"hi\""`, expected: []string{"--referer", "a b", `say "hi"`}},
+		{name: "Empty quoted argument", input: `--user-agent ""`, expected: []string{"--user-agent", ""}},
+		{name: "Quotes inside a word", input: `--output=some" "thing`, expected: []string{"--output=some thing"}},
+		{name: "Unterminated quote", input: `--match-filter "duration > 60`, wantErr: true},
+		{name: "Trailing backslash", input: `--foo \`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitArgs(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("splitArgs(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("splitArgs(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package skool
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+)
+
+// stderrTail remembers the last non-empty line written to it, so yt-dlp's own
+// explanation can be added to the error after it exits
+type stderrTail struct {
+	mu      sync.Mutex
+	partial []byte
+	last    string
+}
+
+// Write keeps the line in progress and the last complete one; it never fails
+// so it can sit behind an io.MultiWriter next to os.Stderr
+func (t *stderrTail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.partial = append(t.partial, p...)
+	for {
+		i := bytes.IndexAny(t.partial, "\r\n")
+		if i < 0 {
+			break
+		}
+		if line := strings.TrimSpace(string(t.partial[:i])); line != "" {
+			t.last = line
+		}
+		t.partial = t.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// Line returns the last non-empty line, including one without a trailing
+// newline, with yt-dlp's "ERROR:" prefix removed
+func (t *stderrTail) Line() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	line := t.last
+	if rest := strings.TrimSpace(string(t.partial)); rest != "" {
+		line = rest
+	}
+	return strings.TrimSpace(strings.TrimPrefix(line, "ERROR:"))
+}
+
+// tooLargeMessage is what yt-dlp prints when --max-filesize makes it skip a
+// video, which it otherwise treats as a success
+const tooLargeMessage = "File is larger than max-filesize"
+
+// outputWatch reports whether yt-dlp printed a message, even when it's split
+// across writes
+type outputWatch struct {
+	mu      sync.Mutex
+	message []byte
+	tail    []byte
+	seen    bool
+}
+
+func newOutputWatch(message string) *outputWatch {
+	return &outputWatch{message: []byte(message)}
+}
+
+// Write never fails, like stderrTail, so it can sit behind an io.MultiWriter
+func (w *outputWatch) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.seen {
+		return len(p), nil
+	}
+	buf := append(w.tail, p...)
+	if bytes.Contains(buf, w.message) {
+		w.seen = true
+		w.tail = nil
+		return len(p), nil
+	}
+	// Only a message split across this write and the next can still match
+	if keep := len(w.message) - 1; len(buf) > keep {
+		buf = buf[len(buf)-keep:]
+	}
+	w.tail = append([]byte(nil), buf...)
+	return len(p), nil
+}
+
+// Seen reports whether the message was written
+func (w *outputWatch) Seen() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.seen
+}
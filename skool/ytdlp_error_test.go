@@ -0,0 +1,130 @@
+package skool
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestStderrTail(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		want   string
+	}{
+		{"Nothing written", nil, ""},
+		{"Single error", []string{"ERROR: [loom] abc: Private video\n"}, "[loom] abc: Private video"},
+		{"Last line wins", []string{"WARNING: slow\n", "ERROR: Sign in to confirm\n"}, "Sign in to confirm"},
+		{"Trailing blank lines", []string{"ERROR: gone\n\n  \n"}, "gone"},
+		{"Split across writes", []string{"ERROR: Unsupp", "orted URL\n"}, "Unsupported URL"},
+		{"No trailing newline", []string{"first\n", "ERROR: cut off"}, "cut off"},
+		{"Carriage returns", []string{"progress 10%\rERROR: broken pipe\r\n"}, "broken pipe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tail := &stderrTail{}
+			for _, w := range tt.writes {
+				if _, err := tail.Write([]byte(w)); err != nil {
+					t.Fatalf("Write() error: %v", err)
+				}
+			}
+			if got := tail.Line(); got != tt.want {
+				t.Errorf("Line() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutputWatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		want   bool
+	}{
+		{"Nothing written", nil, false},
+		{"Whole line", []string{"[download] File is larger than max-filesize (2000 bytes > 1000 bytes). Aborting.\n"}, true},
+		{"Split across writes", []string{"[download] File is lar", "ger than max-", "filesize (2000 bytes > 1000 bytes)\n"}, true},
+		{"Other output", []string{"[download] Video already in archive\n", "[download] 100% of 1.00MiB\n"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watch := newOutputWatch(tooLargeMessage)
+			for _, w := range tt.writes {
+				if _, err := watch.Write([]byte(w)); err != nil {
+					t.Fatalf("Write() error: %v", err)
+				}
+			}
+			if got := watch.Seen(); got != tt.want {
+				t.Errorf("Seen() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDownloadWithYtDlp_ErrorIncludesStderr(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake yt-dlp is a shell script")
+	}
+
+	fakeYtDlp := filepath.Join(t.TempDir(), "yt-dlp")
+	script := "#!/bin/sh\necho '[loom] abc: Downloading webpage' >&2\necho 'ERROR: [loom] abc: Private video' >&2\nexit 1\n"
+	if err := os.WriteFile(fakeYtDlp, []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create fake yt-dlp: %v", err)
+	}
+
+	config := Config{YtDlpPath: fakeYtDlp, OutputDir: t.TempDir()}
+	_, err := downloadWithYtDlp(context.Background(), VideoEntry{URL: "https://www.loom.com/share/abc"}, config, nil)
+	if err == nil {
+		t.Fatal("Expected an error when yt-dlp exits non-zero")
+	}
+	if !strings.Contains(err.Error(), "exit status 1") || !strings.HasSuffix(err.Error(), ": [loom] abc: Private video") {
+		t.Errorf("Expected the exit status and yt-dlp's error, got %q", err)
+	}
+}
+
+func TestDownloadWithYtDlp_TooLarge(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake yt-dlp is a shell script")
+	}
+
+	// Like yt-dlp over --max-filesize: a message on stdout, success, no file
+	fakeYtDlp := filepath.Join(t.TempDir(), "yt-dlp")
+	script := "#!/bin/sh\necho '[download] File is larger than max-filesize (2000000 bytes > 1000 bytes). Aborting.'\n"
+	if err := os.WriteFile(fakeYtDlp, []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create fake yt-dlp: %v", err)
+	}
+
+	config := Config{YtDlpPath: fakeYtDlp, OutputDir: t.TempDir(), MaxFilesize: "1K", Quiet: true}
+	_, err := downloadWithYtDlp(context.Background(), VideoEntry{URL: "https://www.loom.com/share/abc"}, config, nil)
+	if !errors.Is(err, errTooLarge) {
+		t.Errorf("Expected errTooLarge, got %v", err)
+	}
+}
+
+func TestDownloadWithYtDlp_NoPathIsNotTooLarge(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake yt-dlp is a shell script")
+	}
+
+	// Like a --download-archive hit: success and no file, but no size message
+	fakeYtDlp := filepath.Join(t.TempDir(), "yt-dlp")
+	script := "#!/bin/sh\necho '[download] abc: has already been recorded in the archive'\n"
+	if err := os.WriteFile(fakeYtDlp, []byte(script), 0755); err != nil {
+		t.Fatalf("Failed to create fake yt-dlp: %v", err)
+	}
+
+	config := Config{YtDlpPath: fakeYtDlp, OutputDir: t.TempDir(), MaxFilesize: "1K", Quiet: true}
+	path, err := downloadWithYtDlp(context.Background(), VideoEntry{URL: "https://www.loom.com/share/abc"}, config, nil)
+	if err != nil {
+		t.Errorf("Expected no error without yt-dlp's max-filesize message, got %v", err)
+	}
+	if path != "" {
+		t.Errorf("Expected no path, got %q", path)
+	}
+}
@@ -0,0 +1,77 @@
+package skool
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ytDlpMaxAge is how old the installed yt-dlp may be before the preflight
+// warns. Loom and YouTube change often enough that older releases break.
+const ytDlpMaxAge = 90 * 24 * time.Hour
+
+// ytDlpVersionTimeout bounds yt-dlp --version, which should be instant
+const ytDlpVersionTimeout = 30 * time.Second
+
+// parseYtDlpVersion returns the release date of a yt-dlp version, which is
+// the date itself, e.g. 2024.08.06, optionally followed by a patch or
+// nightly build number
+func parseYtDlpVersion(version string) (time.Time, error) {
+	parts := strings.Split(strings.TrimSpace(version), ".")
+	if len(parts) < 3 {
+		return time.Time{}, fmt.Errorf("unrecognized yt-dlp version %q", version)
+	}
+	released, err := time.Parse("2006.01.02", strings.Join(parts[:3], "."))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unrecognized yt-dlp version %q", version)
+	}
+	return released, nil
+}
+
+// ytDlpAge returns how long before now the version was released, and whether
+// that's more than maxAge
+func ytDlpAge(version string, now time.Time, maxAge time.Duration) (time.Duration, bool, error) {
+	released, err := parseYtDlpVersion(version)
+	if err != nil {
+		return 0, false, err
+	}
+	age := now.Sub(released)
+	return age, age > maxAge, nil
+}
+
+// checkYtDlpVersion warns when the installed yt-dlp is older than
+// ytDlpMaxAge. Failing to tell the version is only logged in verbose mode.
+func checkYtDlpVersion(ytDlpPath string, now time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), ytDlpVersionTimeout)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, ytDlpPath, "--version").Output()
+	if err != nil {
+		console.Debugf("Failed to get the yt-dlp version: %v", err)
+		return
+	}
+	version := strings.TrimSpace(string(output))
+	age, outdated, err := ytDlpAge(version, now, ytDlpMaxAge)
+	if err != nil {
+		console.Debug(err)
+		return
+	}
+	console.Debug("yt-dlp version:", version)
+	if outdated {
+		console.Warningf("yt-dlp %s is %d days old, and old versions often fail on Loom and YouTube; rerun with -update-ytdlp or update it yourself", version, int(age.Hours()/24))
+	}
+}
+
+// updateYtDlp runs yt-dlp -U for -update-ytdlp, showing its output as a log
+func updateYtDlp(ytDlpPath string) error {
+	console.Info("Updating yt-dlp...")
+	cmd := exec.Command(ytDlpPath, "-U")
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("yt-dlp -U failed: %v (if it was installed with pip or a package manager, update it that way)", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package skool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestYtDlpAge(t *testing.T) {
+	now := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		version      string
+		wantDays     int
+		wantOutdated bool
+		wantErr      bool
+	}{
+		{"2024.09.27", 4, false, false},
+		{"2024.07.04\n", 89, false, false},
+		{"2024.07.03", 90, true, false},
+		{"2023.12.30.1", 276, true, false},
+		{"2024.08.06.232317", 56, false, false},
+		{"2024.13.01", 0, false, true},
+		{"1.2", 0, false, true},
+		{"", 0, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			age, outdated, err := ytDlpAge(tt.version, now, ytDlpMaxAge)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ytDlpAge(%q) error = %v, wantErr %v", tt.version, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if days := int(age.Hours() / 24); days != tt.wantDays || outdated != tt.wantOutdated {
+				t.Errorf("ytDlpAge(%q) = %d days, outdated %v, want %d days, outdated %v", tt.version, days, outdated, tt.wantDays, tt.wantOutdated)
+			}
+		})
+	}
+}